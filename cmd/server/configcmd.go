@@ -0,0 +1,322 @@
+package main
+
+import (
+	"dill-monitor/internal/config"
+	"dill-monitor/internal/models"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// configUsage describes the "config" subcommands
+const configUsage = `Usage: dill-monitor [-config path] config <command> [flags] [address]
+
+Commands:
+  add [flags] <address>     add an address to monitor
+  remove <address>          stop monitoring an address
+  list [-json]              list the monitored addresses
+  show [-json] <address>    show the configuration of an address
+  validate                  check the config file for problems
+  migrate [flags]           convert legacy config files to the current schema version
+`
+
+// runConfig implements the "config" command, which manages the addresses of
+// the config file. A running server picks up the changes without a restart.
+func runConfig(args []string) error {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, configUsage)
+		return fmt.Errorf("no config command given")
+	}
+
+	switch args[0] {
+	case "add":
+		return runConfigAdd(args[1:])
+	case "remove":
+		return runConfigRemove(args[1:])
+	case "list":
+		return runConfigList(args[1:])
+	case "show":
+		return runConfigShow(args[1:])
+	case "validate":
+		return runConfigValidate(args[1:])
+	case "migrate":
+		return runConfigMigrate(args[1:])
+	default:
+		fmt.Fprint(os.Stderr, configUsage)
+		return fmt.Errorf("unknown config command: %s", args[0])
+	}
+}
+
+// runConfigAdd adds an address and saves the config if it is still valid
+func runConfigAdd(args []string) error {
+	fs := newConfigFlagSet("add", "add [flags] <address>")
+	label := fs.String("label", "", "label of the address")
+	network := fs.String("network", "", "network of the address (default network if empty)")
+	interval := fs.String("interval", "", "polling interval override, e.g. \"15m\" or \"epoch\"")
+	discover := fs.Bool("discover", false, "discover further validators from deposits")
+	var validators, indices listFlag
+	fs.Var(&validators, "validator", "validator pubkey; may be repeated or comma separated")
+	fs.Var(&indices, "index", "validator index; may be repeated or comma separated")
+	fs.Parse(args)
+
+	address, err := addressArg(fs)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		return err
+	}
+
+	addr := models.Address{
+		Label:            *label,
+		Address:          address,
+		ValidatorIndices: indices,
+		Discover:         *discover,
+		Network:          *network,
+		Interval:         *interval,
+	}
+	if len(validators) > 0 {
+		addr.ValidatorAddress = validators[0]
+		addr.ValidatorAddresses = validators[1:]
+	}
+	if err := cfg.AddAddress(addr); err != nil {
+		return fmt.Errorf("%s: %v", address, err)
+	}
+	// 저장하기 전에 새 주소를 포함한 전체 설정을 검증
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	if err := config.SaveConfig(*configPath, cfg); err != nil {
+		return err
+	}
+	fmt.Printf("Added address %s to %s\n", address, *configPath)
+	return nil
+}
+
+// runConfigRemove removes an address and saves the config
+func runConfigRemove(args []string) error {
+	fs := newConfigFlagSet("remove", "remove <address>")
+	fs.Parse(args)
+
+	address, err := addressArg(fs)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		return err
+	}
+	if err := cfg.RemoveAddress(address); err != nil {
+		return fmt.Errorf("%s: %v", address, err)
+	}
+
+	if err := config.SaveConfig(*configPath, cfg); err != nil {
+		return err
+	}
+	fmt.Printf("Removed address %s from %s\n", address, *configPath)
+	return nil
+}
+
+// runConfigList prints the configured addresses as a table or JSON
+func runConfigList(args []string) error {
+	fs := newConfigFlagSet("list", "list [-json]")
+	asJSON := fs.Bool("json", false, "print JSON instead of a table")
+	fs.Parse(args)
+
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		return err
+	}
+
+	addresses := cfg.ListAddresses()
+	if *asJSON {
+		if addresses == nil {
+			addresses = []models.Address{}
+		}
+		return printJSON(addresses)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LABEL\tADDRESS\tNETWORK\tINTERVAL\tVALIDATORS")
+	for _, addr := range addresses {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\n", orDash(addr.Label), addr.Address, orDash(addr.Network),
+			orDash(addr.Interval), len(addr.Pubkeys())+len(addr.ValidatorIndices))
+	}
+	return w.Flush()
+}
+
+// runConfigShow prints the configuration of one address
+func runConfigShow(args []string) error {
+	fs := newConfigFlagSet("show", "show [-json] <address>")
+	asJSON := fs.Bool("json", false, "print JSON instead of text")
+	fs.Parse(args)
+
+	address, err := addressArg(fs)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		return err
+	}
+	addr, err := cfg.GetAddress(address)
+	if err != nil {
+		return fmt.Errorf("%s: %v", address, err)
+	}
+
+	if *asJSON {
+		return printJSON(addr)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Label:\t%s\n", orDash(addr.Label))
+	fmt.Fprintf(w, "Address:\t%s\n", addr.Address)
+	fmt.Fprintf(w, "Network:\t%s\n", orDash(addr.Network))
+	fmt.Fprintf(w, "Interval:\t%s\n", orDash(addr.Interval))
+	fmt.Fprintf(w, "Discover:\t%t\n", addr.Discover)
+	fmt.Fprintf(w, "Validators:\t%s\n", orDash(strings.Join(addr.Pubkeys(), ", ")))
+	fmt.Fprintf(w, "Validator indices:\t%s\n", orDash(strings.Join(addr.ValidatorIndices, ", ")))
+	return w.Flush()
+}
+
+// runConfigValidate checks the config file and reports every problem
+func runConfigValidate(args []string) error {
+	fs := newConfigFlagSet("validate", "validate")
+	fs.Parse(args)
+
+	// 검증을 위해 기본 설정 파일이 만들어지지 않도록 존재 여부를 먼저 확인
+	if _, err := os.Stat(*configPath); err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s is valid: %d addresses\n", *configPath, len(cfg.ListAddresses()))
+	return nil
+}
+
+// runConfigMigrate converts a legacy address file and server config file into
+// a unified config document of the current schema version
+func runConfigMigrate(args []string) error {
+	fs := newConfigFlagSet("migrate", "migrate [-output path] [-dry-run]")
+	output := fs.String("output", "", "path of the migrated config; defaults to the -config path, keeping the original as <path>.bak")
+	dryRun := fs.Bool("dry-run", false, "print the migrated config instead of saving it")
+	fs.Parse(args)
+
+	original, err := os.ReadFile(*configPath)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		return err
+	}
+	if doc := cfg.Document(); doc != nil {
+		fmt.Printf("%s already uses schema version %d\n", *configPath, doc.Version)
+		return nil
+	}
+
+	serverCfg, err := config.LoadServerConfig(*serverConfigPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return fmt.Errorf("failed to load server config %s: %v", *serverConfigPath, err)
+		}
+		// 서버 설정이 없으면 빈 섹션으로 두어 로드 시 기본값이 적용되도록 함
+		fmt.Printf("No server config at %s, the migrated config uses the default server settings\n", *serverConfigPath)
+		serverCfg = &models.ServerConfig{}
+	}
+
+	doc := config.NewDocument(serverCfg, cfg.ListAddresses())
+	path := *output
+	if path == "" {
+		path = *configPath
+	}
+
+	if *dryRun {
+		data, err := config.EncodeDocument(path, doc)
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+
+	if path == *configPath {
+		info, err := os.Stat(*configPath)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(*configPath+".bak", original, info.Mode().Perm()); err != nil {
+			return fmt.Errorf("failed to back up %s: %v", *configPath, err)
+		}
+		fmt.Printf("Saved the original config as %s.bak\n", *configPath)
+	}
+	if err := config.SaveDocument(path, doc); err != nil {
+		return err
+	}
+
+	fmt.Printf("Migrated %s and %s to schema version %d in %s\n", *configPath, *serverConfigPath, config.CurrentVersion, path)
+	fmt.Printf("The server settings are now read from %s; -server-config is no longer needed\n", path)
+	return nil
+}
+
+// newConfigFlagSet creates the flag set of a config command
+func newConfigFlagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet("config "+name, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: dill-monitor [-config path] config %s\n", usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// addressArg returns the single address argument of a config command
+func addressArg(fs *flag.FlagSet) (string, error) {
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return "", fmt.Errorf("expected exactly one address, got %d arguments", fs.NArg())
+	}
+	return fs.Arg(0), nil
+}
+
+// printJSON prints v as indented JSON
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(v)
+}
+
+// orDash returns "-" for empty table cells
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// listFlag collects the values of a flag that may be repeated or comma separated
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(value string) error {
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*l = append(*l, item)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"dill-monitor/internal/models"
+	"dill-monitor/internal/repository"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	// historyCompactInterval is how often old snapshots are downsampled
+	historyCompactInterval = time.Hour
+	// defaultHistoryRange is the range returned when a query sets no "from"
+	defaultHistoryRange = 24 * time.Hour
+)
+
+// historyHandler serves the history of an address or validator on a network,
+// which defaults to defaultNetwork:
+//
+//	/api/history?address=0x...&network=<name>&from=<RFC 3339>&to=<RFC 3339>&resolution=raw|hourly|daily
+//	/api/history?validator=<index>&...
+func historyHandler(repo repository.Repository, defaultNetwork string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query, err := parseHistoryQuery(r.URL.Query(), defaultNetwork, time.Now())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		snapshots, err := repo.QueryHistory(r.Context(), query)
+		if errors.Is(err, repository.ErrHistoryUnavailable) {
+			http.Error(w, err.Error(), http.StatusNotImplemented)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if snapshots == nil {
+			snapshots = []*models.Snapshot{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(snapshots)
+	}
+}
+
+// parseHistoryQuery parses the parameters of a history request
+func parseHistoryQuery(values url.Values, defaultNetwork string, now time.Time) (models.HistoryQuery, error) {
+	query := models.HistoryQuery{
+		Network:    values.Get("network"),
+		To:         now,
+		Resolution: values.Get("resolution"),
+	}
+	if query.Network == "" {
+		query.Network = defaultNetwork
+	}
+
+	address, validator := values.Get("address"), values.Get("validator")
+	switch {
+	case address != "" && validator != "":
+		return query, fmt.Errorf("only one of address and validator may be given")
+	case address != "":
+		query.Subject, query.Key = models.SubjectAddress, address
+	case validator != "":
+		query.Subject, query.Key = models.SubjectValidator, validator
+	default:
+		return query, fmt.Errorf("address or validator is required")
+	}
+
+	if _, err := models.ResolutionPeriod(query.Resolution); err != nil {
+		return query, err
+	}
+
+	var err error
+	if to := values.Get("to"); to != "" {
+		if query.To, err = time.Parse(time.RFC3339, to); err != nil {
+			return query, fmt.Errorf("invalid to %q: %v", to, err)
+		}
+	}
+	query.From = query.To.Add(-defaultHistoryRange)
+	if from := values.Get("from"); from != "" {
+		if query.From, err = time.Parse(time.RFC3339, from); err != nil {
+			return query, fmt.Errorf("invalid from %q: %v", from, err)
+		}
+	}
+	if query.From.After(query.To) {
+		return query, fmt.Errorf("from must not be after to")
+	}
+	return query, nil
+}
+
+// eventsHandler serves the lifecycle events of validators, oldest first:
+//
+//	/api/events?validator=<index>&network=<name>&from=<RFC 3339>&to=<RFC 3339>
+//
+// Every parameter is optional.
+func eventsHandler(repo repository.Repository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		values := r.URL.Query()
+		query := models.EventQuery{
+			ValidatorIdx: values.Get("validator"),
+			Network:      values.Get("network"),
+		}
+		var err error
+		for _, param := range []struct {
+			name string
+			dest *time.Time
+		}{{"from", &query.From}, {"to", &query.To}} {
+			value := values.Get(param.name)
+			if value == "" {
+				continue
+			}
+			if *param.dest, err = time.Parse(time.RFC3339, value); err != nil {
+				http.Error(w, fmt.Sprintf("invalid %s %q: %v", param.name, value, err), http.StatusBadRequest)
+				return
+			}
+		}
+
+		events, err := repo.ListValidatorEvents(r.Context(), query)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if events == nil {
+			events = []*models.ValidatorEvent{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(events)
+	}
+}
+
+// compactHistory downsamples and expires snapshots according to the retention
+// policy, once at startup and then every historyCompactInterval
+func compactHistory(ctx context.Context, repo repository.Repository, policy models.RetentionPolicy) {
+	ticker := time.NewTicker(historyCompactInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := repo.CompactHistory(ctx, policy, time.Now()); err != nil {
+			log.Printf("Error compacting history: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// retentionString describes a retention, where zero keeps snapshots forever
+func retentionString(d time.Duration) string {
+	if d == 0 {
+		return "forever"
+	}
+	return d.String()
+}
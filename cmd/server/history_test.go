@@ -0,0 +1,70 @@
+package main
+
+import (
+	"dill-monitor/internal/models"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseHistoryQuery(t *testing.T) {
+	now := time.Date(2025, 5, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		query string
+		want  models.HistoryQuery
+		err   bool
+	}{
+		{
+			name:  "address with defaults",
+			query: "address=0xabc",
+			want:  models.HistoryQuery{Subject: models.SubjectAddress, Key: "0xabc", Network: "alps", From: now.Add(-defaultHistoryRange), To: now},
+		},
+		{
+			name:  "validator on a network",
+			query: "validator=17021&network=andes&resolution=hourly&from=2025-05-01T00:00:00Z&to=2025-05-02T00:00:00Z",
+			want: models.HistoryQuery{
+				Subject: models.SubjectValidator, Key: "17021", Network: "andes", Resolution: models.ResolutionHourly,
+				From: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2025, 5, 2, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "range relative to to",
+			query: "validator=1&to=2025-05-02T00:00:00Z",
+			want: models.HistoryQuery{
+				Subject: models.SubjectValidator, Key: "1", Network: "alps",
+				From: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2025, 5, 2, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{name: "no subject", query: "network=alps", err: true},
+		{name: "both subjects", query: "address=0xabc&validator=1", err: true},
+		{name: "invalid resolution", query: "validator=1&resolution=weekly", err: true},
+		{name: "invalid from", query: "validator=1&from=yesterday", err: true},
+		{name: "from after to", query: "validator=1&from=2025-05-03T00:00:00Z&to=2025-05-02T00:00:00Z", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := parseHistoryQuery(values, "alps", now)
+			if tt.err {
+				if err == nil {
+					t.Fatalf("parseHistoryQuery = %+v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseHistoryQuery: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseHistoryQuery = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
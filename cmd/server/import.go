@@ -0,0 +1,76 @@
+package main
+
+import (
+	"dill-monitor/internal/config"
+	"dill-monitor/internal/importer"
+	"flag"
+	"fmt"
+)
+
+// runImport implements the "import" command, which adds the validators of
+// deposit data and keystore files to the address config
+func runImport(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	address := fs.String("address", "", "assign all validators to this address instead of their withdrawal address")
+	label := fs.String("label", "", "label of addresses added by the import (default \"imported-<address>\")")
+	network := fs.String("network", "", "network of addresses added by the import")
+	dryRun := fs.Bool("dry-run", false, "report the changes without saving the config")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: dill-monitor [-config path] import [flags] <deposit_data-*.json | keystore-*.json | directory>...\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return fmt.Errorf("no files to import")
+	}
+
+	validators, err := importer.ReadFiles(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %v", err)
+	}
+
+	result := importer.Merge(cfg, validators, importer.Options{
+		Address: *address,
+		Label:   *label,
+		Network: *network,
+	})
+
+	fmt.Printf("Read %d validators from %d paths\n", len(validators), fs.NArg())
+	for _, added := range result.Added {
+		fmt.Printf("Added address %s\n", added)
+	}
+	for _, duplicate := range result.Duplicates {
+		fmt.Printf("Duplicate: %s\n", duplicate)
+	}
+	for _, skipped := range result.Skipped {
+		fmt.Printf("Skipped: %s\n", skipped)
+	}
+	if len(result.Skipped) > 0 {
+		fmt.Println("Keystores without deposit data and BLS withdrawal credentials have no withdrawal address; pass -address to assign them")
+	}
+	fmt.Printf("Imported %d validators (%d duplicates, %d skipped)\n", result.Imported, len(result.Duplicates), len(result.Skipped))
+
+	if result.Imported == 0 {
+		return nil
+	}
+	// 저장하기 전에 가져온 주소를 포함한 전체 설정을 검증 (dry run에서도 문제를 보고)
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if *dryRun {
+		return nil
+	}
+
+	if err := config.SaveConfig(*configPath, cfg); err != nil {
+		return err
+	}
+	fmt.Printf("Saved %s\n", *configPath)
+	return nil
+}
@@ -3,10 +3,15 @@ package main
 import (
 	"context"
 	"dill-monitor/internal/config"
+	"dill-monitor/internal/dillapi"
+	"dill-monitor/internal/discovery"
 	"dill-monitor/internal/models"
 	"dill-monitor/internal/repository"
+	"dill-monitor/internal/scheduler"
 	"dill-monitor/internal/service"
+	"dill-monitor/internal/workerpool"
 	"dill-monitor/pkg/metrics"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"html/template"
@@ -15,6 +20,8 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -23,11 +30,16 @@ import (
 )
 
 var (
-	configPath         = flag.String("config", "", "path to config file")
-	serverConfigPath   = flag.String("server-config", "", "path to server config file")
-	defaultMetricsPort = 9090 // 기본값
+	configPath       = flag.String("config", "", "path to config file")
+	serverConfigPath = flag.String("server-config", "", "path to server config file, used with legacy config files without a server section")
+	// serverFlags override server config fields, e.g. -metrics-port
+	serverFlags = config.RegisterServerFlags(flag.CommandLine)
+	printConfig = flag.Bool("print-config", false, "print the effective config with secrets masked and exit")
 )
 
+// configPollInterval is how often the config file is checked for changes
+const configPollInterval = 5 * time.Second
+
 func getDefaultConfigPath() string {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
@@ -57,47 +69,117 @@ func main() {
 		*serverConfigPath = getDefaultServerConfigPath()
 	}
 
+	// Subcommands
+	if flag.NArg() > 0 {
+		switch flag.Arg(0) {
+		case "import":
+			if err := runImport(flag.Args()[1:]); err != nil {
+				log.Fatalf("Import failed: %v", err)
+			}
+		case "config":
+			if err := runConfig(flag.Args()[1:]); err != nil {
+				log.Fatalf("Config command failed: %v", err)
+			}
+		default:
+			log.Fatalf("Unknown command: %s", flag.Arg(0))
+		}
+		return
+	}
+
 	log.Printf("Using config file: %s", *configPath)
-	log.Printf("Using server config file: %s", *serverConfigPath)
 
 	// config.json 파일이 실제로 존재하는지 확인
 	if _, err := os.Stat(*configPath); os.IsNotExist(err) {
 		log.Fatalf("Config file does not exist: %s", *configPath)
 	}
 
-	// 설정 파일의 내용을 로그에 출력
-	configData, err := os.ReadFile(*configPath)
-	if err != nil {
-		log.Fatalf("Failed to read config file: %v", err)
-	}
-	log.Printf("Config file contents: %s", string(configData))
-
-	// Initialize configuration
-	cfg, err := config.LoadConfig(*configPath)
+	// Initialize configuration; the store swaps it in when the file is reloaded
+	store, err := config.NewStore(*configPath)
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
-	// 초기화 후 cfg 내용 확인
-	log.Printf("Loaded addresses: %d", len(cfg.ListAddresses()))
+	// Initialize server configuration, from the unified config document if
+	// there is one and from the legacy server config file otherwise
+	serverCfg := loadServerConfig(store.Document())
+	if err := config.ApplyServerOverrides(serverCfg, serverFlags); err != nil {
+		log.Fatalf("Invalid server config override: %v", err)
+	}
 
-	// Initialize server configuration
-	serverCfg, err := config.LoadServerConfig(*serverConfigPath)
-	if err != nil {
-		log.Printf("Failed to load server config: %v, using default values", err)
-		serverCfg = &models.ServerConfig{
-			MetricsPort: defaultMetricsPort,
-			LogLevel:    "info",
-			Host:        "0.0.0.0",
+	if *printConfig {
+		if err := printEffectiveConfig(store, serverCfg); err != nil {
+			log.Fatalf("Failed to print config: %v", err)
 		}
+		return
 	}
 
 	// Initialize Prometheus metrics
 	promClient := metrics.NewPrometheusClient()
 	promRepo := repository.NewPrometheusRepository(promClient)
 
+	// Balances and validator rewards are kept in memory by the Prometheus
+	// repository, or persisted and exported through a composite repository
+	storage, err := config.ParseStorage(serverCfg.Storage, *configPath)
+	if err != nil {
+		log.Fatalf("Failed to configure storage: %v", err)
+	}
+	var repo repository.Repository = promRepo
+	if storage.Backend == config.StorageBolt {
+		boltRepo, err := repository.NewBoltRepository(storage.Path)
+		if err != nil {
+			log.Fatalf("Failed to open storage: %v", err)
+		}
+		defer boltRepo.Close()
+
+		composite := repository.NewCompositeRepository(boltRepo, promRepo)
+		restored, err := composite.Restore(context.Background())
+		if err != nil {
+			log.Fatalf("Failed to restore stored balances: %v", err)
+		}
+		log.Printf("Storing balances in %s (restored %d balances)", storage.Path, restored)
+		repo = composite
+	}
+
+	if doc := store.Document(); doc != nil && len(doc.Alerts) > 0 {
+		repo.UpdateAlertThresholds(doc.Alerts)
+	}
+
+	// Resolve network profiles
+	profiles, defaultNetwork, err := config.ResolveNetworks(serverCfg.Network)
+	if err != nil {
+		log.Fatalf("Failed to resolve networks: %v", err)
+	}
+	// 같은 호스트를 사용하는 네트워크는 rate limiter를 공유
+	rateLimiters := dillapi.NewRateLimiters(serverCfg.Upstream.RateLimit, serverCfg.Upstream.RateBurst)
+	var networks []*service.Network
+	for _, name := range config.NetworkNames(profiles) {
+		opts, err := dillapi.OptionsFromConfig(name, serverCfg.Upstream)
+		if err != nil {
+			log.Fatalf("Failed to configure upstream client: %v", err)
+		}
+		opts.RateLimiters = rateLimiters
+		opts.Recorder = repo
+		network, err := service.NewNetwork(name, profiles[name], opts)
+		if err != nil {
+			log.Fatalf("Failed to configure network: %v", err)
+		}
+		networks = append(networks, network)
+	}
+	log.Printf("Configured networks: %v (default: %s)", config.NetworkNames(profiles), defaultNetwork)
+	// 주소 목록 대신 네트워크별 개수만 기록
+	log.Printf("Loaded %s", addressSummary(store.Addresses(), defaultNetwork))
+
 	// Initialize services
-	balanceService := service.NewBalanceService(promRepo)
+	balanceService := service.NewBalanceService(repo, networks, defaultNetwork)
+	// 주소가 사용하는 네트워크에 엔드포인트가 없으면 시작하지 않음
+	if err := balanceService.ValidateAddresses(store.Addresses()); err != nil {
+		log.Fatalf("Invalid address config: %v", err)
+	}
+
+	// 저장소에 남아 있는, 더 이상 설정에 없거나 라벨이 바뀐 주소를 정리
+	if err := balanceService.Reconcile(context.Background(), store.Addresses()); err != nil {
+		log.Printf("Error reconciling stored balances: %v", err)
+	}
 
 	// Create a new ServeMux for routing
 	mux := http.NewServeMux()
@@ -115,7 +197,7 @@ func main() {
 		// Handle web interface
 		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 			tmpl := template.Must(template.ParseFiles("templates/index.html"))
-			addresses := cfg.ListAddresses()
+			addresses := store.Addresses()
 			data := struct {
 				Addresses []models.Address
 			}{
@@ -134,6 +216,26 @@ func main() {
 	// Handle metrics endpoint
 	mux.Handle("/metrics", promhttp.Handler())
 
+	// Handle balances endpoint, including the provenance of every source
+	mux.HandleFunc("/api/balances", func(w http.ResponseWriter, r *http.Request) {
+		balances, err := repo.ListBalances(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if balances == nil {
+			balances = []*models.Balance{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(balances)
+	})
+
+	// Handle history endpoint, served from persistent storage
+	mux.HandleFunc("/api/history", historyHandler(repo, defaultNetwork))
+
+	// Handle validator lifecycle events endpoint
+	mux.HandleFunc("/api/events", eventsHandler(repo))
+
 	// Start the server
 	addr := fmt.Sprintf("%s:%d", serverCfg.Host, serverCfg.MetricsPort)
 	log.Printf("Starting server on %s", addr)
@@ -165,28 +267,102 @@ func main() {
 		close(done)
 	}()
 
-	// 메인 처리 루프 수정
+	// 주소별 스케줄러가 고정 ticker 루프를 대체 (시작 시 모든 주소를 즉시 처리)
+	polling, err := config.ParsePolling(serverCfg.Polling)
+	if err != nil {
+		log.Fatalf("Failed to configure polling: %v", err)
+	}
+	sched := scheduler.New(scheduler.Options{
+		Interval:     polling.Interval,
+		Jitter:       polling.Jitter,
+		EpochAligned: polling.EpochAligned,
+		EpochOffset:  polling.EpochOffset,
+		Clocks:       balanceService,
+		CycleTimeout: polling.CycleTimeout,
+		Overlap:      polling.Overlap,
+		Recorder:     balanceService,
+		Cycles:       repo,
+	})
+	if err := sched.SetAddresses(store.Addresses()); err != nil {
+		log.Fatalf("Failed to schedule addresses: %v", err)
+	}
+	repo.RecordConfigReload(true, time.Now())
+
+	// 설정 파일이 바뀌거나 SIGHUP을 받으면 재시작 없이 주소 설정을 다시 읽음
+	reloadChan := make(chan os.Signal, 1)
+	signal.Notify(reloadChan, syscall.SIGHUP)
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
+		watchConfig(ctx, store, reloadChan, sched, balanceService, repo)
+	}()
+	if polling.EpochAligned {
+		log.Printf("Polling %s after every epoch boundary with up to %s jitter", polling.EpochOffset, polling.Jitter)
+	} else {
+		log.Printf("Polling every %s with up to %s jitter", polling.Interval, polling.Jitter)
+	}
 
-		ticker := time.NewTicker(1 * time.Minute)
-		defer ticker.Stop()
-
-		// 즉시 처리 시작
-		log.Println("Processing addresses on startup...")
-		processAddresses(ctx, cfg, balanceService)
-
-		for {
-			select {
-			case <-ctx.Done():
-				log.Println("Processing loop received cancel signal")
-				return
-			case <-ticker.C:
-				log.Println("Processing addresses on schedule...")
-				processAddresses(ctx, cfg, balanceService)
+	// 입금 내역에서 검증자를 자동으로 찾아 주소에 추가 (opt-in)
+	discoverySettings, err := config.ParseDiscovery(serverCfg.Discovery)
+	if err != nil {
+		log.Fatalf("Failed to configure validator discovery: %v", err)
+	}
+	if discoverySettings.Enabled {
+		scanners := make(map[string]*discovery.DepositScanner)
+		for _, name := range config.NetworkNames(profiles) {
+			profile := profiles[name]
+			if profile.ExecutionRPCURL == "" || profile.DepositContract == "" {
+				continue
 			}
+			scanner, err := discovery.NewDepositScanner(profile.ExecutionRPCURL, profile.DepositContract,
+				profile.DepositContractBlock, discoverySettings.BlockRange)
+			if err != nil {
+				log.Printf("Validator discovery disabled on %s: %v", name, err)
+				continue
+			}
+			scanners[name] = scanner
+		}
+
+		if len(scanners) == 0 {
+			log.Println("Validator discovery is enabled but no network has executionRpcUrl and depositContract configured")
+		} else {
+			discoverer := discovery.New(scanners, defaultNetwork, repo)
+			log.Printf("Discovering validators every %s", discoverySettings.Interval)
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				runDiscovery(ctx, discoverer, discoverySettings, store, sched)
+			}()
 		}
+	}
+
+	// 오래된 이력은 보존 정책에 따라 다운샘플링하고 삭제
+	if storage.Backend == config.StorageBolt {
+		log.Printf("History retention: raw %s, hourly %s, daily %s",
+			retentionString(storage.Retention.Raw), retentionString(storage.Retention.Hourly), retentionString(storage.Retention.Daily))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			compactHistory(ctx, repo, storage.Retention)
+		}()
+	}
+
+	pool := workerpool.New(polling.Workers, repo)
+	log.Printf("Processing up to %d addresses concurrently", polling.Workers)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		sched.Run(ctx, func(ctx context.Context, due []models.Address) {
+			// 사이클마다 설정에서 빠진 주소와 이전 라벨의 시리즈를 정리
+			if err := balanceService.Reconcile(ctx, store.Addresses()); err != nil {
+				log.Printf("Error reconciling metric series: %v", err)
+			}
+			processAddresses(ctx, due, pool, balanceService)
+		})
+		log.Println("Processing loop received cancel signal")
 	}()
 
 	// 모든 고루틴이 완료될 때까지 대기
@@ -200,62 +376,204 @@ func main() {
 	log.Println("Shutting down gracefully...")
 }
 
-func processAddresses(ctx context.Context, cfg *config.Config, balanceService *service.BalanceService) {
-	addresses := cfg.ListAddresses()
+// loadServerConfig returns the server settings of the unified config document,
+// or reads the legacy server config file if doc is nil
+func loadServerConfig(doc *config.Document) *models.ServerConfig {
+	if doc != nil {
+		if flagGiven("server-config") {
+			log.Printf("Ignoring server config file %s: the server settings are read from %s", *serverConfigPath, *configPath)
+		}
+		log.Printf("Using config schema version %d", doc.Version)
+		return doc.ServerConfig()
+	}
 
-	// Create a wait group to wait for all goroutines to finish
-	var wg sync.WaitGroup
-	wg.Add(len(addresses))
+	log.Printf("Using server config file: %s", *serverConfigPath)
+	serverCfg, err := config.LoadServerConfig(*serverConfigPath)
+	if err != nil {
+		log.Printf("Failed to load server config: %v, using default values", err)
+		return config.DefaultServerConfig()
+	}
+	return serverCfg
+}
+
+// printEffectiveConfig prints the config the server would run with, after
+// defaults and overrides, as a unified document with sensitive fields masked
+func printEffectiveConfig(store *config.Store, serverCfg *models.ServerConfig) error {
+	var alerts models.AlertsConfig
+	if loaded := store.Document(); loaded != nil {
+		alerts = loaded.Alerts
+	}
+	doc, err := effectiveDocument(serverCfg, store.Addresses(), alerts)
+	if err != nil {
+		return err
+	}
+
+	redacted, err := config.Redacted(doc)
+	if err != nil {
+		return err
+	}
+	return printJSON(redacted)
+}
 
-	// Create a channel for results
-	resultCh := make(chan struct {
-		addr    string
-		balance *models.Balance
-		err     error
-	}, len(addresses))
+// effectiveDocument returns the unified document of the given settings with
+// the configured network profiles merged over the built-in ones
+func effectiveDocument(serverCfg *models.ServerConfig, addresses []models.Address, alerts models.AlertsConfig) (*config.Document, error) {
+	profiles, defaultNetwork, err := config.ResolveNetworks(serverCfg.Network)
+	if err != nil {
+		return nil, err
+	}
 
-	// Process each address in a separate goroutine
+	doc := config.NewDocument(serverCfg, addresses)
+	doc.Networks = models.NetworkConfig{Default: defaultNetwork, Profiles: profiles}
+	doc.Alerts = alerts
+	return doc, nil
+}
+
+// addressSummary describes the monitored addresses by their number per network
+func addressSummary(addresses []models.Address, defaultNetwork string) string {
+	type counts struct{ addresses, validators int }
+	perNetwork := make(map[string]*counts)
+	var networks []string
 	for _, addr := range addresses {
-		go func(addr models.Address) {
-			defer wg.Done()
+		network := addr.Network
+		if network == "" {
+			network = defaultNetwork
+		}
+		if perNetwork[network] == nil {
+			perNetwork[network] = &counts{}
+			networks = append(networks, network)
+		}
+		perNetwork[network].addresses++
+		perNetwork[network].validators += len(addr.Pubkeys()) + len(addr.ValidatorIndices)
+	}
+	sort.Strings(networks)
 
-			balance, err := balanceService.ProcessAddress(ctx, addr)
-			resultCh <- struct {
-				addr    string
-				balance *models.Balance
-				err     error
-			}{addr.Address, balance, err}
-		}(addr)
+	parts := make([]string, 0, len(networks))
+	for _, network := range networks {
+		c := perNetwork[network]
+		parts = append(parts, fmt.Sprintf("%s: %d addresses, %d validators", network, c.addresses, c.validators))
+	}
+	if len(parts) == 0 {
+		return "0 addresses"
 	}
+	return fmt.Sprintf("%d addresses (%s)", len(addresses), strings.Join(parts, "; "))
+}
 
-	// Close the result channel once all goroutines are done
-	go func() {
-		wg.Wait()
-		close(resultCh)
-	}()
+// flagGiven reports whether a flag was set on the command line
+func flagGiven(name string) bool {
+	given := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			given = true
+		}
+	})
+	return given
+}
 
-	// Collect all processed balances for summary metrics
-	var processedBalances []*models.Balance
+// watchConfig reloads the address config when the file changes or a signal
+// arrives on reload. A config that fails validation is rejected and the
+// running one is kept.
+func watchConfig(ctx context.Context, store *config.Store, reload <-chan os.Signal, sched *scheduler.Scheduler, balanceService *service.BalanceService, recorder repository.Repository) {
+	ticker := time.NewTicker(configPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sig := <-reload:
+			log.Printf("Received %v, reloading config", sig)
+		case <-ticker.C:
+			if !store.Changed() {
+				continue
+			}
+			log.Printf("Config file %s changed, reloading", store.Path())
+		}
 
-	// Process results as they come in
-	for result := range resultCh {
-		if result.err != nil {
-			log.Printf("Error processing address %s: %v", result.addr, result.err)
+		removed, err := store.Reload(func(cfg *config.Config) error {
+			if err := balanceService.ValidateAddresses(cfg.ListAddresses()); err != nil {
+				return err
+			}
+			return sched.Validate(cfg.ListAddresses())
+		})
+		if err != nil {
+			log.Printf("Rejected config reload, keeping the current config: %v", err)
+			if err := recorder.RecordConfigReload(false, time.Now()); err != nil {
+				log.Printf("Error recording config reload: %v", err)
+			}
 			continue
 		}
-		log.Printf("Processed address %s: balance=%s, staking=%s, reward=%s",
-			result.addr, result.balance.Balance, result.balance.StakingBalance, result.balance.Reward)
 
-		// Collect successful results for summary metrics
-		processedBalances = append(processedBalances, result.balance)
+		addresses := store.Addresses()
+		if err := sched.SetAddresses(addresses); err != nil {
+			// Validate가 통과했으므로 발생하지 않아야 함
+			log.Printf("Error scheduling reloaded addresses: %v", err)
+		}
+		// 삭제와 라벨 변경 모두 Reconcile에 맡겨 라벨만 바뀐 주소의 잔액을 유지
+		if err := balanceService.Reconcile(ctx, addresses); err != nil {
+			log.Printf("Error reconciling reloaded addresses: %v", err)
+		}
+		for _, addr := range removed {
+			log.Printf("Stopped monitoring address %s (%s)", addr.Address, addr.Label)
+		}
+		if err := recorder.RecordConfigReload(true, time.Now()); err != nil {
+			log.Printf("Error recording config reload: %v", err)
+		}
+		log.Printf("Reloaded config: %d addresses, %d removed", len(addresses), len(removed))
+	}
+}
+
+// runDiscovery adds the validators discovered from deposits to the configured
+// addresses every interval, reschedules them and optionally saves the config
+func runDiscovery(ctx context.Context, discoverer *discovery.Discoverer, settings *config.Discovery, store *config.Store, sched *scheduler.Scheduler) {
+	ticker := time.NewTicker(settings.Interval)
+	defer ticker.Stop()
+
+	for {
+		if discovered := discoverer.Discover(ctx, store.Addresses()); len(discovered) > 0 {
+			// 탐색 중 다시 읽힌 설정을 덮어쓰지 않도록 아직 없는 검증자만 추가
+			var added []discovery.Validator
+			err := store.Update(func(current []models.Address) []models.Address {
+				added = discovery.Merge(current, discovered)
+				return current
+			}, settings.Persist)
+			switch {
+			case err != nil:
+				log.Printf("Error saving discovered validators: %v", err)
+			case len(added) > 0:
+				discoverer.Record(added)
+				if settings.Persist {
+					log.Printf("Saved discovered validators to %s", store.Path())
+				}
+				if err := sched.SetAddresses(store.Addresses()); err != nil {
+					log.Printf("Error scheduling discovered validators: %v", err)
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
 	}
+}
+
+func processAddresses(ctx context.Context, addresses []models.Address, pool *workerpool.Pool, balanceService *service.BalanceService) {
+	// Process the addresses on the bounded worker pool; the service also
+	// records the history of the cycle
+	processedBalances := balanceService.ProcessAddresses(ctx, addresses, pool)
 
-	// Update summary metrics with all processed balances
+	// Update summary metrics with the latest balances of all addresses, since
+	// only the addresses that were due have been processed in this run
 	if len(processedBalances) > 0 {
-		if err := balanceService.UpdateSummaryMetrics(ctx, processedBalances); err != nil {
+		allBalances, err := balanceService.ListBalances(ctx)
+		if err != nil {
+			log.Printf("Error listing balances: %v", err)
+		} else if err := balanceService.UpdateSummaryMetrics(ctx, allBalances); err != nil {
 			log.Printf("Error updating summary metrics: %v", err)
 		} else {
-			log.Printf("Updated summary metrics with %d addresses", len(processedBalances))
+			log.Printf("Updated summary metrics with %d addresses", len(allBalances))
 		}
 
 		// Process validator information to update validator-specific metrics
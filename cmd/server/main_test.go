@@ -0,0 +1,80 @@
+package main
+
+import (
+	"dill-monitor/internal/config"
+	"dill-monitor/internal/models"
+	"testing"
+)
+
+func TestAddressSummary(t *testing.T) {
+	tests := []struct {
+		name      string
+		addresses []models.Address
+		want      string
+	}{
+		{"empty", nil, "0 addresses"},
+		{
+			name: "default network",
+			addresses: []models.Address{
+				{Address: "0x1", ValidatorAddress: "0xaa"},
+				{Address: "0x2", ValidatorIndices: []string{"1", "2"}},
+			},
+			want: "2 addresses (alps: 2 addresses, 3 validators)",
+		},
+		{
+			name: "per network without addresses or labels",
+			addresses: []models.Address{
+				{Label: "secret", Address: "0x1", Network: "mainnet"},
+				{Address: "0x2", ValidatorAddresses: []string{"0xaa", "0xbb"}},
+				{Address: "0x3", Network: "alps", ValidatorAddress: "0xcc", ValidatorAddresses: []string{"0xcc"}},
+			},
+			want: "3 addresses (alps: 2 addresses, 3 validators; mainnet: 1 addresses, 0 validators)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := addressSummary(tt.addresses, "alps"); got != tt.want {
+				t.Errorf("addressSummary = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEffectiveDocument(t *testing.T) {
+	serverCfg := &models.ServerConfig{
+		Network: models.NetworkConfig{
+			Profiles: map[string]models.NetworkProfile{
+				"andes": {StakerURL: "https://andes-staker/api", ExecutionRPCURL: "https://rpc.example.com/v2/secret-key"},
+			},
+		},
+	}
+
+	doc, err := effectiveDocument(serverCfg, nil, models.AlertsConfig{"min_balance": 32})
+	if err != nil {
+		t.Fatalf("effectiveDocument: %v", err)
+	}
+	redacted, err := config.Redacted(doc)
+	if err != nil {
+		t.Fatalf("Redacted: %v", err)
+	}
+	networks := redacted.(*config.Document).Networks
+
+	if networks.Default != "alps" {
+		t.Errorf("default network = %q, want alps", networks.Default)
+	}
+	// 설정하지 않은 내장 프로필도 병합된 값으로 출력
+	if alps := networks.Profiles["alps"]; alps.ExplorerURL != "https://alps.dill.xyz/api/trpc" || alps.SlotsPerEpoch != 32 {
+		t.Errorf("alps profile = %+v, want the built-in profile", alps)
+	}
+	andes := networks.Profiles["andes"]
+	if andes.ExplorerURL != "https://andes.dill.xyz/api/trpc" || andes.StakerURL != "https://andes-staker/api" {
+		t.Errorf("andes profile = %+v, want the configured fields merged over the built-in profile", andes)
+	}
+	if andes.ExecutionRPCURL != "https://rpc.example.com/<redacted>" {
+		t.Errorf("andes executionRpcUrl = %q, want it masked", andes.ExecutionRPCURL)
+	}
+	if doc.Alerts["min_balance"] != 32 {
+		t.Errorf("alerts = %v, want the loaded alerts", doc.Alerts)
+	}
+}
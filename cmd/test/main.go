@@ -46,6 +46,7 @@ func main() {
 		Balance:      "36000.566",
 		Status:       "active_ongoing",
 		UserLabel:    "Test Validator",
+		Network:      "alps",
 	}
 
 	// 저장소에 밸리데이터 보상 정보 저장
@@ -58,6 +59,7 @@ func main() {
 	balance := &models.Balance{
 		Label:                 "Test Validator",
 		Address:               "0xFEFCa083D55C196605ab3733BF5000c2B43861b4",
+		Network:               "alps",
 		ValidatorAddress:      "0x8e7b68fcc8813303debf815208a8fe3fe4b7fc557ce87e6bd419f6bf05064dde800056d69f11266a7b1d88cc72f5c6af",
 		ValidatorIndex:        "17021",
 		Status:                "active_ongoing",
@@ -86,6 +88,7 @@ func main() {
 	balance2 := &models.Balance{
 		Label:                 "My Validator", // 다른 사용자 지정 라벨
 		Address:               "0xcC195833442B8D6142DDA28a31dc4881425Ebf28",
+		Network:               "alps",
 		ValidatorAddress:      "0xb0ec80500de5ad5e8e316f71312ed6d4d2837f744f8b41950b0e570b90ecd6f8126a058ea8da03a10dd740c1e3395212",
 		ValidatorIndex:        "17022",
 		Status:                "active_ongoing",
@@ -116,6 +119,7 @@ func main() {
 		Balance:      "36000.322",
 		Status:       "active_ongoing",
 		UserLabel:    "My Validator", // 두 번째 사용자 지정 라벨
+		Network:      "alps",
 	}
 
 	// 저장소에 두 번째 밸리데이터 보상 정보 저장
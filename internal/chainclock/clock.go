@@ -0,0 +1,60 @@
+package chainclock
+
+import (
+	"fmt"
+	"time"
+)
+
+// Clock converts between wall-clock time and the slots and epochs of a chain
+type Clock struct {
+	genesis       time.Time
+	slotDuration  time.Duration
+	slotsPerEpoch uint64
+}
+
+// New creates a clock for a chain that started at genesis
+func New(genesis time.Time, secondsPerSlot, slotsPerEpoch uint64) (*Clock, error) {
+	if genesis.IsZero() {
+		return nil, fmt.Errorf("genesis time is not set")
+	}
+	if secondsPerSlot == 0 || slotsPerEpoch == 0 {
+		return nil, fmt.Errorf("secondsPerSlot and slotsPerEpoch must be positive")
+	}
+
+	return &Clock{
+		genesis:       genesis,
+		slotDuration:  time.Duration(secondsPerSlot) * time.Second,
+		slotsPerEpoch: slotsPerEpoch,
+	}, nil
+}
+
+// EpochDuration returns the length of an epoch
+func (c *Clock) EpochDuration() time.Duration {
+	return c.slotDuration * time.Duration(c.slotsPerEpoch)
+}
+
+// SlotAt returns the slot in progress at t, or 0 before genesis
+func (c *Clock) SlotAt(t time.Time) uint64 {
+	if t.Before(c.genesis) {
+		return 0
+	}
+	return uint64(t.Sub(c.genesis) / c.slotDuration)
+}
+
+// EpochAt returns the epoch in progress at t, or 0 before genesis
+func (c *Clock) EpochAt(t time.Time) uint64 {
+	return c.SlotAt(t) / c.slotsPerEpoch
+}
+
+// EpochStart returns the time the given epoch starts
+func (c *Clock) EpochStart(epoch uint64) time.Time {
+	return c.genesis.Add(time.Duration(epoch) * c.EpochDuration())
+}
+
+// NextEpochStart returns the start of the first epoch beginning after t
+func (c *Clock) NextEpochStart(t time.Time) time.Time {
+	if t.Before(c.genesis) {
+		return c.genesis
+	}
+	return c.EpochStart(c.EpochAt(t) + 1)
+}
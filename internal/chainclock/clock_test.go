@@ -0,0 +1,76 @@
+package chainclock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	genesis := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name           string
+		genesis        time.Time
+		secondsPerSlot uint64
+		slotsPerEpoch  uint64
+		err            bool
+	}{
+		{"valid", genesis, 12, 32, false},
+		{"zero genesis", time.Time{}, 12, 32, true},
+		{"zero slot duration", genesis, 0, 32, true},
+		{"zero slots per epoch", genesis, 12, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.genesis, tt.secondsPerSlot, tt.slotsPerEpoch)
+			if (err != nil) != tt.err {
+				t.Errorf("New error = %v, want error %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestClock(t *testing.T) {
+	genesis := time.Unix(1700000000, 0)
+	clock, err := New(genesis, 12, 32)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	epoch := 384 * time.Second
+
+	if got := clock.EpochDuration(); got != epoch {
+		t.Fatalf("EpochDuration = %s, want %s", got, epoch)
+	}
+
+	tests := []struct {
+		name  string
+		t     time.Time
+		slot  uint64
+		epoch uint64
+		next  time.Time
+	}{
+		{"before genesis", genesis.Add(-time.Hour), 0, 0, genesis},
+		{"genesis", genesis, 0, 0, genesis.Add(epoch)},
+		{"within the first slot", genesis.Add(11 * time.Second), 0, 0, genesis.Add(epoch)},
+		{"second slot", genesis.Add(12 * time.Second), 1, 0, genesis.Add(epoch)},
+		{"last slot of an epoch", genesis.Add(epoch - time.Second), 31, 0, genesis.Add(epoch)},
+		// 경계 시각에는 다음 경계를 반환해 같은 경계에서 두 번 실행하지 않음
+		{"epoch boundary", genesis.Add(3 * epoch), 96, 3, genesis.Add(4 * epoch)},
+		{"within an epoch", genesis.Add(3*epoch + time.Minute), 101, 3, genesis.Add(4 * epoch)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := clock.SlotAt(tt.t); got != tt.slot {
+				t.Errorf("SlotAt = %d, want %d", got, tt.slot)
+			}
+			if got := clock.EpochAt(tt.t); got != tt.epoch {
+				t.Errorf("EpochAt = %d, want %d", got, tt.epoch)
+			}
+			if got := clock.NextEpochStart(tt.t); !got.Equal(tt.next) {
+				t.Errorf("NextEpochStart = %s, want %s", got, tt.next)
+			}
+		})
+	}
+}
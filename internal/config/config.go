@@ -1,19 +1,28 @@
 package config
 
 import (
+	"bytes"
 	"dill-monitor/internal/models"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
 )
 
 // Config represents the application configuration
 type Config struct {
 	Addresses []models.Address `json:"addresses"`
+
+	// document is the unified document the addresses were loaded from, or nil
+	// for a legacy address file; it is written back by SaveConfig
+	document *Document
 }
 
-// LoadConfig loads the configuration from a JSON file
+// LoadConfig loads the configuration from a JSON, TOML or YAML file, selected
+// by the file extension
 func LoadConfig(configPath string) (*Config, error) {
 	// Ensure the config directory exists
 	configDir := filepath.Dir(configPath)
@@ -39,33 +48,132 @@ func LoadConfig(configPath string) (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %v", err)
 	}
 
+	data, err = toJSON(Format(configPath), data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %v", configPath, err)
+	}
+	version, err := schemaVersion(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %v", configPath, err)
+	}
+
 	var config Config
-	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("failed to parse config file: %v", err)
+	var target interface{} = &config
+	if version > 0 {
+		config.document = &Document{}
+		target = config.document
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %v", configPath, parseError(data, err))
+	}
+	if config.document != nil {
+		config.Addresses = config.document.Addresses
+	}
+
+	// 알 수 없는 필드와 잘못된 값을 모두 모아 한 번에 보고
+	problems := unknownFields("", data, reflect.TypeOf(target))
+	problems = append(problems, config.problems()...)
+	if err := validationError(problems); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %v", configPath, err)
 	}
 
 	return &config, nil
 }
 
-// SaveConfig saves the configuration to a JSON file
+// Document returns the unified document the config was loaded from, or nil
+// if it was loaded from a legacy address file
+func (c *Config) Document() *Document {
+	return c.document
+}
+
+// parseError adds the position of syntax errors and the JSON path of type
+// errors to a decoding error
+func parseError(data []byte, err error) error {
+	switch e := err.(type) {
+	case *json.SyntaxError:
+		line := 1 + bytes.Count(data[:e.Offset], []byte("\n"))
+		return fmt.Errorf("line %d: %v", line, e)
+	case *json.UnmarshalTypeError:
+		return fmt.Errorf("%s: expected %s, got JSON %s", jsonPath(e.Field), e.Type, e.Value)
+	}
+	return err
+}
+
+// jsonPath converts the dotted field path of a decoding error, e.g.
+// "addresses.0.label", into the form used by validation errors
+func jsonPath(field string) string {
+	var path string
+	for _, part := range strings.Split(field, ".") {
+		if _, err := strconv.Atoi(part); err == nil {
+			path += "[" + part + "]"
+			continue
+		}
+		path = joinPath(path, part)
+	}
+	return path
+}
+
+// SaveConfig saves the configuration in the format of its file extension. The file is replaced
+// atomically, so readers never see a partially written config, and keeps the
+// permissions of the file it replaces.
 func SaveConfig(configPath string, config *Config) error {
-	data, err := json.MarshalIndent(config, "", "  ")
+	var v interface{} = config
+	if config.document != nil {
+		// 통합 문서는 주소 외의 섹션을 유지한 채 저장
+		doc := *config.document
+		doc.Addresses = config.Addresses
+		v = &doc
+	}
+
+	data, err := encodeFile(configPath, v)
 	if err != nil {
-		return fmt.Errorf("failed to marshal config: %v", err)
+		return err
 	}
 
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
+	if err := writeFileAtomic(configPath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write config file: %v", err)
 	}
 
 	return nil
 }
 
+// writeFileAtomic writes data to a temporary file in the directory of path and
+// renames it over path. perm is used if path does not exist yet.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	// rename이 성공하면 임시 파일은 이미 없으므로 삭제는 무시됨
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
 // AddAddress adds a new address to the configuration
 func (c *Config) AddAddress(address models.Address) error {
 	// Check if address already exists
 	for _, addr := range c.Addresses {
-		if addr.Address == address.Address {
+		if strings.EqualFold(addr.Address, address.Address) {
 			return fmt.Errorf("address already exists")
 		}
 	}
@@ -77,7 +185,7 @@ func (c *Config) AddAddress(address models.Address) error {
 // RemoveAddress removes an address from the configuration
 func (c *Config) RemoveAddress(address string) error {
 	for i, addr := range c.Addresses {
-		if addr.Address == address {
+		if strings.EqualFold(addr.Address, address) {
 			c.Addresses = append(c.Addresses[:i], c.Addresses[i+1:]...)
 			return nil
 		}
@@ -88,7 +196,7 @@ func (c *Config) RemoveAddress(address string) error {
 // GetAddress returns an address from the configuration
 func (c *Config) GetAddress(address string) (*models.Address, error) {
 	for _, addr := range c.Addresses {
-		if addr.Address == address {
+		if strings.EqualFold(addr.Address, address) {
 			return &addr, nil
 		}
 	}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"dill-monitor/internal/models"
+	"encoding/json"
+	"fmt"
+)
+
+// CurrentVersion is the schema version of the unified config document
+// written by this build. Version 0 denotes the legacy layout of a separate
+// address file and server config file.
+const CurrentVersion = 1
+
+// Defaults used for server settings the config leaves empty
+const (
+	DefaultMetricsPort = 9090
+	DefaultLogLevel    = "info"
+	DefaultHost        = "0.0.0.0"
+)
+
+// Document is the unified config document holding the server settings,
+// networks, monitored addresses and alert thresholds in one file
+type Document struct {
+	Version   int                  `json:"version"`
+	Server    models.ServerSection `json:"server"`
+	Networks  models.NetworkConfig `json:"networks"`
+	Addresses []models.Address     `json:"addresses"`
+	Alerts    models.AlertsConfig  `json:"alerts,omitempty"`
+}
+
+// NewDocument creates a document of the current version from a legacy server
+// config and address list
+func NewDocument(server *models.ServerConfig, addresses []models.Address) *Document {
+	if addresses == nil {
+		addresses = []models.Address{}
+	}
+	return &Document{
+		Version: CurrentVersion,
+		Server: models.ServerSection{
+			MetricsPort: server.MetricsPort,
+			LogLevel:    server.LogLevel,
+			Host:        server.Host,
+			Upstream:    server.Upstream,
+			Polling:     server.Polling,
+			Discovery:   server.Discovery,
+			Storage:     server.Storage,
+		},
+		Networks:  server.Network,
+		Addresses: addresses,
+	}
+}
+
+// ServerConfig returns the server settings and networks of the document,
+// with the defaults applied to empty settings
+func (d *Document) ServerConfig() *models.ServerConfig {
+	cfg := &models.ServerConfig{
+		MetricsPort: d.Server.MetricsPort,
+		LogLevel:    d.Server.LogLevel,
+		Host:        d.Server.Host,
+		Network:     d.Networks,
+		Upstream:    d.Server.Upstream,
+		Polling:     d.Server.Polling,
+		Discovery:   d.Server.Discovery,
+		Storage:     d.Server.Storage,
+	}
+	if cfg.MetricsPort == 0 {
+		cfg.MetricsPort = DefaultMetricsPort
+	}
+	if cfg.LogLevel == "" {
+		cfg.LogLevel = DefaultLogLevel
+	}
+	if cfg.Host == "" {
+		cfg.Host = DefaultHost
+	}
+	return cfg
+}
+
+// DefaultServerConfig returns the server config used when there is none
+func DefaultServerConfig() *models.ServerConfig {
+	return (&Document{}).ServerConfig()
+}
+
+// SaveDocument writes a unified config document in the format of path
+func SaveDocument(path string, doc *Document) error {
+	return SaveConfig(path, &Config{Addresses: doc.Addresses, document: doc})
+}
+
+// EncodeDocument encodes a unified config document in the format of path
+func EncodeDocument(path string, doc *Document) ([]byte, error) {
+	return encodeFile(path, doc)
+}
+
+// schemaVersion returns the schema version of JSON encoded config. Documents
+// without a version key are legacy address files, unless they have one of
+// the sections of the unified layout.
+func schemaVersion(data []byte) (int, error) {
+	var sections map[string]json.RawMessage
+	if err := json.Unmarshal(data, &sections); err != nil {
+		// 구문 오류는 이후의 디코딩에서 위치와 함께 보고됨
+		return 0, nil
+	}
+
+	raw, ok := sections["version"]
+	if !ok {
+		for _, section := range []string{"server", "networks", "alerts"} {
+			if _, unified := sections[section]; unified {
+				return 0, fmt.Errorf("version: required in a config with a %s section", section)
+			}
+		}
+		return 0, nil
+	}
+
+	var version int
+	if err := json.Unmarshal(raw, &version); err != nil || version < 1 {
+		return 0, fmt.Errorf("version: invalid schema version %s", raw)
+	}
+	if version > CurrentVersion {
+		return 0, fmt.Errorf("version: schema version %d is newer than the supported version %d", version, CurrentVersion)
+	}
+	return version, nil
+}
@@ -0,0 +1,156 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Config file formats, selected by file extension
+const (
+	FormatJSON = "json"
+	FormatTOML = "toml"
+	FormatYAML = "yaml"
+)
+
+// Format returns the format of a config file from its extension; files
+// without a known extension are JSON
+func Format(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		return FormatTOML
+	case ".yaml", ".yml":
+		return FormatYAML
+	default:
+		return FormatJSON
+	}
+}
+
+// toJSON converts the contents of a TOML or YAML config file to JSON, so that
+// every format is decoded and validated through the json tags of the models
+func toJSON(format string, data []byte) ([]byte, error) {
+	var doc map[string]interface{}
+	switch format {
+	case FormatTOML:
+		if _, err := toml.Decode(string(data), &doc); err != nil {
+			return nil, err
+		}
+	case FormatYAML:
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+	default:
+		return data, nil
+	}
+
+	// 빈 YAML 파일은 nil 맵으로 디코딩됨
+	if doc == nil {
+		doc = map[string]interface{}{}
+	}
+	return json.Marshal(doc)
+}
+
+// fromJSON converts JSON encoded config to the given format
+func fromJSON(format string, data []byte) ([]byte, error) {
+	switch format {
+	case FormatTOML:
+		// 숫자를 float64로 읽으면 9090이 9090.0으로 쓰이고 큰 정수의 정밀도가 손실됨
+		var doc map[string]interface{}
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		if err := dec.Decode(&doc); err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := toml.NewEncoder(&buf).Encode(tomlNumbers(doc)); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+
+	case FormatYAML:
+		// JSON은 YAML이므로 노드로 읽으면 필드 순서가 유지됨
+		var node yaml.Node
+		if err := yaml.Unmarshal(data, &node); err != nil {
+			return nil, err
+		}
+		blockStyle(&node)
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(&node); err != nil {
+			return nil, err
+		}
+		if err := enc.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	return data, nil
+}
+
+// tomlNumbers replaces the json.Number values in v by integers, keeping
+// their exact value, or by floats if they have a fraction or exponent
+func tomlNumbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = tomlNumbers(value)
+		}
+		return v
+	case []interface{}:
+		for i, value := range v {
+			v[i] = tomlNumbers(value)
+		}
+		return v
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		if n, err := strconv.ParseUint(v.String(), 10, 64); err == nil {
+			return n
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	default:
+		return v
+	}
+}
+
+// blockStyle resets the flow and quoting style a node got from being parsed
+// as JSON, so that it is written as plain block YAML
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+// decodeFile decodes the contents of a config file in the format of path into
+// v and returns the JSON form of the contents
+func decodeFile(path string, data []byte, v interface{}) ([]byte, error) {
+	data, err := toJSON(Format(path), data)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, parseError(data, err)
+	}
+	return data, nil
+}
+
+// encodeFile encodes v in the format of path
+func encodeFile(path string, v interface{}) ([]byte, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %v", err)
+	}
+	return fromJSON(Format(path), data)
+}
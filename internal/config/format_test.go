@@ -0,0 +1,132 @@
+package config
+
+import (
+	"dill-monitor/internal/models"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"config.json", FormatJSON},
+		{"config", FormatJSON},
+		{"config.toml", FormatTOML},
+		{"config.TOML", FormatTOML},
+		{"config.yaml", FormatYAML},
+		{"/etc/dill/config.yml", FormatYAML},
+	}
+
+	for _, tt := range tests {
+		if got := Format(tt.path); got != tt.want {
+			t.Errorf("Format(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestLoadConfigFormats(t *testing.T) {
+	want := []models.Address{
+		{Label: "a", Address: lowerAddress, ValidatorIndices: []string{"42"}, Interval: "15m"},
+	}
+
+	tests := []struct {
+		name     string
+		contents string
+	}{
+		{"config.json", `{"addresses": [{"label": "a", "address": "` + lowerAddress + `", "validator_indices": ["42"], "interval": "15m"}]}`},
+		{"config.toml", "[[addresses]]\nlabel = \"a\"\naddress = \"" + lowerAddress + "\"\nvalidator_indices = [\"42\"]\ninterval = \"15m\"\n"},
+		{"config.yaml", "addresses:\n  - label: a\n    address: \"" + lowerAddress + "\"\n    validator_indices: [\"42\"]\n    interval: 15m\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := LoadConfig(writeConfig(t, tt.name, tt.contents))
+			if err != nil {
+				t.Fatalf("LoadConfig: %v", err)
+			}
+			if !reflect.DeepEqual(cfg.Addresses, want) {
+				t.Errorf("addresses = %+v, want %+v", cfg.Addresses, want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigFormatErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     string
+	}{
+		{"config.toml", "[[addresses]]\nlabel = \"a\"\nextra = 1\naddress = \"" + lowerAddress + "\"\n", "addresses[0].extra: unknown field"},
+		{"config.yaml", "addresses:\n  - label: a\n    address: [\n", "failed to parse config file"},
+		{"config.yaml", "addresses:\n  - label: a\n    address: \"" + lowerAddress + "\"\n    validator_indices: 42\n", "addresses[0].validator_indices: expected []string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadConfig(writeConfig(t, tt.name, tt.contents))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("LoadConfig error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromJSONNumbers(t *testing.T) {
+	data := []byte(`{"metricsPort": 9090, "upstream": {"retryBudget": 0.2}, "network": {"profiles": {"alps": {"genesisTime": -1, "depositContractBlock": 9007199254740993}}}}`)
+
+	tests := []struct {
+		format string
+		want   []string
+	}{
+		{FormatTOML, []string{"metricsPort = 9090\n", "retryBudget = 0.2\n", "genesisTime = -1\n", "depositContractBlock = 9007199254740993\n"}},
+		{FormatYAML, []string{"metricsPort: 9090\n", "retryBudget: 0.2\n", "genesisTime: -1\n", "depositContractBlock: 9007199254740993\n"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			out, err := fromJSON(tt.format, data)
+			if err != nil {
+				t.Fatalf("fromJSON: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(out), want) {
+					t.Errorf("output does not contain %q:\n%s", want, out)
+				}
+			}
+		})
+	}
+}
+
+func TestServerConfigRoundTrip(t *testing.T) {
+	want := &models.ServerConfig{
+		MetricsPort: 9090,
+		Host:        "0.0.0.0",
+		Network: models.NetworkConfig{
+			Default: "alps",
+			Profiles: map[string]models.NetworkProfile{
+				"alps": {GenesisTime: 1700000000, DepositContractBlock: 9007199254740993},
+			},
+		},
+		Upstream: models.UpstreamConfig{RetryBudget: 0.25, MaxRetries: 3},
+	}
+
+	for _, name := range []string{"server.json", "server.toml", "server.yaml"} {
+		t.Run(name, func(t *testing.T) {
+			data, err := encodeFile(name, want)
+			if err != nil {
+				t.Fatalf("encodeFile: %v", err)
+			}
+			got, err := LoadServerConfig(writeConfig(t, name, string(data)))
+			if err != nil {
+				t.Fatalf("LoadServerConfig: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("server config = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
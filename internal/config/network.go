@@ -0,0 +1,121 @@
+package config
+
+import (
+	"dill-monitor/internal/models"
+	"fmt"
+	"sort"
+)
+
+// DefaultNetwork is the network used when neither the server config nor an address names one
+const DefaultNetwork = "alps"
+
+// builtinNetworks holds the profiles known without any configuration. The
+// andes staker API and the mainnet endpoints are not published yet, so they
+// must be set in the server config before an address can use those networks,
+// as must the genesis times used for epoch-aligned polling.
+var builtinNetworks = map[string]models.NetworkProfile{
+	"alps": {
+		ExplorerURL:    "https://alps.dill.xyz/api/trpc",
+		StakerURL:      "https://staker.dill.xyz/api",
+		GweiDecimals:   9,
+		WeiDecimals:    18,
+		SecondsPerSlot: 12,
+		SlotsPerEpoch:  32,
+	},
+	"andes": {
+		ExplorerURL:    "https://andes.dill.xyz/api/trpc",
+		GweiDecimals:   9,
+		WeiDecimals:    18,
+		SecondsPerSlot: 12,
+		SlotsPerEpoch:  32,
+	},
+	"mainnet": {
+		GweiDecimals:   9,
+		WeiDecimals:    18,
+		SecondsPerSlot: 12,
+		SlotsPerEpoch:  32,
+	},
+}
+
+// ResolveNetworks merges the configured profiles over the built-in ones and
+// returns every profile together with the name of the default network.
+// Profiles may lack endpoints; they are rejected once an address uses them.
+func ResolveNetworks(cfg models.NetworkConfig) (map[string]models.NetworkProfile, string, error) {
+	profiles := make(map[string]models.NetworkProfile, len(builtinNetworks)+len(cfg.Profiles))
+	for name, profile := range builtinNetworks {
+		profiles[name] = profile
+	}
+
+	for name, override := range cfg.Profiles {
+		profiles[name] = mergeProfile(profiles[name], override)
+	}
+
+	defaultNetwork := cfg.Default
+	if defaultNetwork == "" {
+		defaultNetwork = DefaultNetwork
+	}
+	if _, exists := profiles[defaultNetwork]; !exists {
+		return nil, "", fmt.Errorf("default network %q is unknown (available: %v)", defaultNetwork, NetworkNames(profiles))
+	}
+
+	return profiles, defaultNetwork, nil
+}
+
+// NetworkNames returns the sorted names of the given profiles
+func NetworkNames(profiles map[string]models.NetworkProfile) []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// mergeProfile overrides the fields of base that are set in override
+func mergeProfile(base, override models.NetworkProfile) models.NetworkProfile {
+	if override.ExplorerURL != "" {
+		base.ExplorerURL = override.ExplorerURL
+	}
+	if override.StakerURL != "" {
+		base.StakerURL = override.StakerURL
+	}
+	if override.ExecutionRPCURL != "" {
+		base.ExecutionRPCURL = override.ExecutionRPCURL
+	}
+	if override.GweiDecimals != 0 {
+		base.GweiDecimals = override.GweiDecimals
+	}
+	if override.WeiDecimals != 0 {
+		base.WeiDecimals = override.WeiDecimals
+	}
+	if override.GenesisTime != 0 {
+		base.GenesisTime = override.GenesisTime
+	}
+	if override.SecondsPerSlot != 0 {
+		base.SecondsPerSlot = override.SecondsPerSlot
+	}
+	if override.SlotsPerEpoch != 0 {
+		base.SlotsPerEpoch = override.SlotsPerEpoch
+	}
+	if override.DepositContract != "" {
+		base.DepositContract = override.DepositContract
+	}
+	if override.DepositContractBlock != 0 {
+		base.DepositContractBlock = override.DepositContractBlock
+	}
+
+	// 사용자 정의 프로필에서 단위를 생략한 경우 기본 단위 사용
+	if base.GweiDecimals == 0 {
+		base.GweiDecimals = 9
+	}
+	if base.WeiDecimals == 0 {
+		base.WeiDecimals = 18
+	}
+	if base.SecondsPerSlot == 0 {
+		base.SecondsPerSlot = 12
+	}
+	if base.SlotsPerEpoch == 0 {
+		base.SlotsPerEpoch = 32
+	}
+	return base
+}
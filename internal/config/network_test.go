@@ -0,0 +1,86 @@
+package config
+
+import (
+	"dill-monitor/internal/models"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResolveNetworks(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     models.NetworkConfig
+		names   []string
+		network string
+		err     string
+	}{
+		{
+			name:    "built-in profiles",
+			names:   []string{"alps", "andes", "mainnet"},
+			network: "alps",
+		},
+		{
+			name: "custom network as default",
+			cfg: models.NetworkConfig{
+				Default:  "devnet",
+				Profiles: map[string]models.NetworkProfile{"devnet": {ExplorerURL: "https://explorer", StakerURL: "https://staker"}},
+			},
+			names:   []string{"alps", "andes", "devnet", "mainnet"},
+			network: "devnet",
+		},
+		{
+			name: "unknown default",
+			cfg:  models.NetworkConfig{Default: "devnet"},
+			err:  `default network "devnet" is unknown`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profiles, network, err := ResolveNetworks(tt.cfg)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("ResolveNetworks error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveNetworks: %v", err)
+			}
+			if names := NetworkNames(profiles); !reflect.DeepEqual(names, tt.names) || network != tt.network {
+				t.Errorf("networks = %v, default %s, want %v, default %s", names, network, tt.names, tt.network)
+			}
+		})
+	}
+}
+
+func TestResolveNetworksMerge(t *testing.T) {
+	profiles, _, err := ResolveNetworks(models.NetworkConfig{
+		Profiles: map[string]models.NetworkProfile{
+			"andes":  {StakerURL: "https://andes-staker/api", GenesisTime: 1700000000},
+			"devnet": {ExplorerURL: "https://explorer", StakerURL: "https://staker", SlotsPerEpoch: 16},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ResolveNetworks: %v", err)
+	}
+
+	want := map[string]models.NetworkProfile{
+		// 설정한 필드만 내장 프로필을 덮어씀
+		"andes": {
+			ExplorerURL: "https://andes.dill.xyz/api/trpc", StakerURL: "https://andes-staker/api", GenesisTime: 1700000000,
+			GweiDecimals: 9, WeiDecimals: 18, SecondsPerSlot: 12, SlotsPerEpoch: 32,
+		},
+		// 사용자 정의 프로필은 생략한 단위와 시계 설정에 기본값 사용
+		"devnet": {
+			ExplorerURL: "https://explorer", StakerURL: "https://staker",
+			GweiDecimals: 9, WeiDecimals: 18, SecondsPerSlot: 12, SlotsPerEpoch: 16,
+		},
+	}
+	for name, profile := range want {
+		if !reflect.DeepEqual(profiles[name], profile) {
+			t.Errorf("profile %s = %+v, want %+v", name, profiles[name], profile)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package config
+
+import (
+	"dill-monitor/internal/models"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// EnvPrefix prefixes the environment variables overriding server config fields
+const EnvPrefix = "DILL_MONITOR_"
+
+// setting is a scalar or JSON encoded field of the server config that can be
+// overridden through the environment or a flag
+type setting struct {
+	// path is the JSON path of the field, e.g. "polling.interval"
+	path string
+	// env is the environment variable, e.g. DILL_MONITOR_POLLING_INTERVAL
+	env string
+	// flag is the command-line flag, e.g. polling-interval
+	flag string
+	// index is the field index path within models.ServerConfig
+	index []int
+}
+
+// serverSettings lists every overridable field of models.ServerConfig
+var serverSettings = collectSettings(reflect.TypeOf(models.ServerConfig{}), nil, nil, nil)
+
+// collectSettings walks the fields of t. Nested structs are descended into;
+// every other field is a setting, with maps and slices given as JSON.
+func collectSettings(t reflect.Type, names []string, index []int, settings []setting) []setting {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if field.PkgPath != "" || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fieldNames := append(append([]string(nil), names...), name)
+		fieldIndex := append(append([]int(nil), index...), i)
+		if field.Type.Kind() == reflect.Struct {
+			settings = collectSettings(field.Type, fieldNames, fieldIndex, settings)
+			continue
+		}
+
+		words := make([]string, 0, len(fieldNames))
+		for _, n := range fieldNames {
+			words = append(words, splitWords(n)...)
+		}
+		settings = append(settings, setting{
+			path:  strings.Join(fieldNames, "."),
+			env:   EnvPrefix + strings.ToUpper(strings.Join(words, "_")),
+			flag:  strings.Join(words, "-"),
+			index: fieldIndex,
+		})
+	}
+	return settings
+}
+
+// splitWords splits a camelCase name into lower case words, keeping
+// abbreviations such as "RPC" together
+func splitWords(name string) []string {
+	var words []string
+	runes := []rune(name)
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		lowerBefore := unicode.IsLower(runes[i-1])
+		upperStartsWord := unicode.IsUpper(runes[i]) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if unicode.IsUpper(runes[i]) && (lowerBefore || upperStartsWord) {
+			words = append(words, strings.ToLower(string(runes[start:i])))
+			start = i
+		}
+	}
+	return append(words, strings.ToLower(string(runes[start:])))
+}
+
+// ServerFlags holds the server config overrides given on the command line
+type ServerFlags struct {
+	fs     *flag.FlagSet
+	values map[string]*string
+}
+
+// RegisterServerFlags adds a flag for every server config field to fs
+func RegisterServerFlags(fs *flag.FlagSet) *ServerFlags {
+	flags := &ServerFlags{fs: fs, values: make(map[string]*string, len(serverSettings))}
+	for _, s := range serverSettings {
+		flags.values[s.flag] = fs.String(s.flag, "", fmt.Sprintf("override %s of the server config (env %s)", s.path, s.env))
+	}
+	return flags
+}
+
+// set returns the flags that were given explicitly
+func (f *ServerFlags) set() map[string]string {
+	set := make(map[string]string)
+	if f == nil {
+		return set
+	}
+	f.fs.Visit(func(fl *flag.Flag) {
+		if value, ok := f.values[fl.Name]; ok {
+			set[fl.Name] = *value
+		}
+	})
+	return set
+}
+
+// ApplyServerOverrides overrides the fields of cfg with the DILL_MONITOR_*
+// environment variables and then with the given flags, so the precedence is
+// flags > environment > server config file > defaults. flags may be nil.
+func ApplyServerOverrides(cfg *models.ServerConfig, flags *ServerFlags) error {
+	given := flags.set()
+	root := reflect.ValueOf(cfg).Elem()
+
+	var problems []string
+	for _, s := range serverSettings {
+		if value, ok := os.LookupEnv(s.env); ok {
+			if err := setValue(root.FieldByIndex(s.index), value); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %v", s.env, err))
+			}
+		}
+		if value, ok := given[s.flag]; ok {
+			if err := setValue(root.FieldByIndex(s.index), value); err != nil {
+				problems = append(problems, fmt.Sprintf("-%s: %v", s.flag, err))
+			}
+		}
+	}
+	return validationError(problems)
+}
+
+// setValue parses value into a field of the server config
+func setValue(field reflect.Value, value string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", value)
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", value)
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid unsigned integer %q", value)
+		}
+		field.SetUint(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", value)
+		}
+		field.SetFloat(f)
+	default:
+		// 맵과 슬라이스는 JSON으로 지정 (예: network.profiles)
+		target := reflect.New(field.Type())
+		if err := json.Unmarshal([]byte(value), target.Interface()); err != nil {
+			return fmt.Errorf("invalid JSON value: %v", err)
+		}
+		field.Set(target.Elem())
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"dill-monitor/internal/models"
+	"flag"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"host", []string{"host"}},
+		{"metricsPort", []string{"metrics", "port"}},
+		{"executionRpcUrl", []string{"execution", "rpc", "url"}},
+		{"RPCTimeout", []string{"rpc", "timeout"}},
+	}
+
+	for _, tt := range tests {
+		if got := splitWords(tt.name); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitWords(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestServerSettings(t *testing.T) {
+	tests := []struct {
+		path string
+		env  string
+		flag string
+	}{
+		{"metricsPort", "DILL_MONITOR_METRICS_PORT", "metrics-port"},
+		{"polling.interval", "DILL_MONITOR_POLLING_INTERVAL", "polling-interval"},
+		{"network.profiles", "DILL_MONITOR_NETWORK_PROFILES", "network-profiles"},
+		{"storage.history.rawRetention", "DILL_MONITOR_STORAGE_HISTORY_RAW_RETENTION", "storage-history-raw-retention"},
+	}
+
+	for _, tt := range tests {
+		var found *setting
+		for i := range serverSettings {
+			if serverSettings[i].path == tt.path {
+				found = &serverSettings[i]
+			}
+		}
+		if found == nil {
+			t.Errorf("no setting for %s", tt.path)
+			continue
+		}
+		if found.env != tt.env || found.flag != tt.flag {
+			t.Errorf("setting %s = %s, -%s, want %s, -%s", tt.path, found.env, found.flag, tt.env, tt.flag)
+		}
+	}
+}
+
+func TestApplyServerOverrides(t *testing.T) {
+	tests := []struct {
+		name  string
+		env   map[string]string
+		args  []string
+		check func(t *testing.T, cfg *models.ServerConfig)
+		err   string
+	}{
+		{
+			name: "file values without overrides",
+			check: func(t *testing.T, cfg *models.ServerConfig) {
+				if cfg.MetricsPort != 9090 || cfg.Host != "127.0.0.1" {
+					t.Errorf("port, host = %d, %q, want the file values", cfg.MetricsPort, cfg.Host)
+				}
+			},
+		},
+		{
+			name: "environment over file",
+			env:  map[string]string{"DILL_MONITOR_METRICS_PORT": "9100", "DILL_MONITOR_DISCOVERY_ENABLED": "true"},
+			check: func(t *testing.T, cfg *models.ServerConfig) {
+				if cfg.MetricsPort != 9100 || !cfg.Discovery.Enabled {
+					t.Errorf("port, discovery = %d, %v, want 9100, true", cfg.MetricsPort, cfg.Discovery.Enabled)
+				}
+				if cfg.Host != "127.0.0.1" {
+					t.Errorf("host = %q, want the file value", cfg.Host)
+				}
+			},
+		},
+		{
+			name: "flags over environment",
+			env:  map[string]string{"DILL_MONITOR_METRICS_PORT": "9100", "DILL_MONITOR_HOST": "0.0.0.0"},
+			args: []string{"-metrics-port", "9200", "-upstream-retry-budget", "0.5"},
+			check: func(t *testing.T, cfg *models.ServerConfig) {
+				if cfg.MetricsPort != 9200 || cfg.Host != "0.0.0.0" || cfg.Upstream.RetryBudget != 0.5 {
+					t.Errorf("port, host, retry budget = %d, %q, %v, want 9200, %q, 0.5", cfg.MetricsPort, cfg.Host, cfg.Upstream.RetryBudget, "0.0.0.0")
+				}
+			},
+		},
+		{
+			name: "JSON encoded map",
+			env:  map[string]string{"DILL_MONITOR_NETWORK_PROFILES": `{"andes": {"explorerUrl": "https://explorer", "stakerUrl": "https://staker"}}`},
+			check: func(t *testing.T, cfg *models.ServerConfig) {
+				if cfg.Network.Profiles["andes"].StakerURL != "https://staker" {
+					t.Errorf("profiles = %+v, want andes", cfg.Network.Profiles)
+				}
+			},
+		},
+		{
+			name: "invalid values",
+			env:  map[string]string{"DILL_MONITOR_METRICS_PORT": "http", "DILL_MONITOR_NETWORK_PROFILES": "{"},
+			args: []string{"-discovery-persist", "maybe"},
+			err:  "3 problems",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			flags := RegisterServerFlags(fs)
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg := &models.ServerConfig{MetricsPort: 9090, Host: "127.0.0.1"}
+			err := ApplyServerOverrides(cfg, flags)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("ApplyServerOverrides error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ApplyServerOverrides: %v", err)
+			}
+			tt.check(t, cfg)
+		})
+	}
+}
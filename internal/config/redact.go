@@ -0,0 +1,75 @@
+package config
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+)
+
+// redactedValue replaces the value of sensitive fields
+const redactedValue = "<redacted>"
+
+// Redacted returns a deep copy of the config value v, which must be a
+// pointer, with every string field tagged `redact:"true"` masked
+func Redacted(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	copied := reflect.New(reflect.TypeOf(v).Elem())
+	if err := json.Unmarshal(data, copied.Interface()); err != nil {
+		return nil, err
+	}
+
+	redact(copied.Elem(), false)
+	return copied.Interface(), nil
+}
+
+// redact masks the sensitive strings below v, which must be addressable;
+// sensitive is set for the values of a field tagged `redact:"true"`
+func redact(v reflect.Value, sensitive bool) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			redact(v.Elem(), sensitive)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			redact(v.Field(i), sensitive || field.Tag.Get("redact") == "true")
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			redact(v.Index(i), sensitive)
+		}
+	case reflect.Map:
+		// 맵의 값은 주소를 가질 수 없으므로 복사본을 마스킹한 뒤 다시 저장
+		for _, key := range v.MapKeys() {
+			value := reflect.New(v.Type().Elem()).Elem()
+			value.Set(v.MapIndex(key))
+			redact(value, sensitive)
+			v.SetMapIndex(key, value)
+		}
+	case reflect.String:
+		if sensitive && v.String() != "" {
+			v.SetString(mask(v.String()))
+		}
+	}
+}
+
+// mask hides a sensitive value. URLs keep their scheme and host, so it stays
+// visible which endpoint is used while credentials in the user info, path or
+// query are hidden.
+func mask(value string) string {
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return redactedValue
+	}
+	if u.User == nil && (u.Path == "" || u.Path == "/") && u.RawQuery == "" {
+		return value
+	}
+	return u.Scheme + "://" + u.Host + "/" + redactedValue
+}
@@ -2,22 +2,221 @@ package config
 
 import (
 	"dill-monitor/internal/models"
-	"encoding/json"
+	"fmt"
 	"os"
+	"path/filepath"
+	"time"
 )
 
-// LoadServerConfig loads server configuration from a file
+const (
+	// DefaultPollingInterval is used when the server config does not set polling.interval
+	DefaultPollingInterval = 1 * time.Minute
+	// DefaultEpochOffset leaves the explorer one slot to index the last epoch
+	DefaultEpochOffset = 12 * time.Second
+	// DefaultWorkers is the number of addresses processed concurrently
+	DefaultWorkers = 10
+	// DefaultDiscoveryInterval is the time between two scans of the deposit contract
+	DefaultDiscoveryInterval = 10 * time.Minute
+	// DefaultDiscoveryBlockRange is the number of blocks requested per log query
+	DefaultDiscoveryBlockRange = 10000
+	// DefaultStorageFile is the name of the bolt database next to the config file
+	DefaultStorageFile = "dill-monitor.db"
+	// DefaultRawRetention is how long snapshots are kept at raw resolution
+	DefaultRawRetention = 7 * 24 * time.Hour
+	// DefaultHourlyRetention is how long snapshots are kept at hourly resolution
+	DefaultHourlyRetention = 90 * 24 * time.Hour
+)
+
+// Storage backends
+const (
+	StorageMemory = "memory"
+	StorageBolt   = "bolt"
+)
+
+// LoadServerConfig loads server configuration from a JSON, TOML or YAML file,
+// selected by the file extension
 func LoadServerConfig(path string) (*models.ServerConfig, error) {
-	file, err := os.Open(path)
+	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
 
 	var config models.ServerConfig
-	if err := json.NewDecoder(file).Decode(&config); err != nil {
+	if _, err := decodeFile(path, data, &config); err != nil {
 		return nil, err
 	}
 
 	return &config, nil
 }
+
+// Polling holds the parsed polling section of the server config
+type Polling struct {
+	Interval     time.Duration
+	Jitter       time.Duration
+	EpochAligned bool
+	EpochOffset  time.Duration
+	Workers      int
+	CycleTimeout time.Duration
+	Overlap      string
+}
+
+// ParsePolling parses the polling section of the server config
+func ParsePolling(cfg models.PollingConfig) (*Polling, error) {
+	polling := &Polling{
+		Interval:    DefaultPollingInterval,
+		EpochOffset: DefaultEpochOffset,
+		Workers:     DefaultWorkers,
+	}
+
+	var err error
+	if cfg.Interval != "" {
+		if polling.Interval, err = time.ParseDuration(cfg.Interval); err != nil {
+			return nil, fmt.Errorf("invalid polling interval %q: %v", cfg.Interval, err)
+		}
+		if polling.Interval <= 0 {
+			return nil, fmt.Errorf("polling interval must be positive")
+		}
+	}
+
+	if cfg.Jitter != "" {
+		if polling.Jitter, err = time.ParseDuration(cfg.Jitter); err != nil {
+			return nil, fmt.Errorf("invalid polling jitter %q: %v", cfg.Jitter, err)
+		}
+	}
+
+	switch cfg.Mode {
+	case "", "interval":
+	case "epoch":
+		polling.EpochAligned = true
+	default:
+		return nil, fmt.Errorf("invalid polling mode %q (expected \"interval\" or \"epoch\")", cfg.Mode)
+	}
+
+	if cfg.EpochOffset != "" {
+		if polling.EpochOffset, err = time.ParseDuration(cfg.EpochOffset); err != nil {
+			return nil, fmt.Errorf("invalid polling epochOffset %q: %v", cfg.EpochOffset, err)
+		}
+	}
+
+	if cfg.CycleTimeout != "" {
+		if polling.CycleTimeout, err = time.ParseDuration(cfg.CycleTimeout); err != nil {
+			return nil, fmt.Errorf("invalid polling cycleTimeout %q: %v", cfg.CycleTimeout, err)
+		}
+		if polling.CycleTimeout <= 0 {
+			return nil, fmt.Errorf("polling cycleTimeout must be positive")
+		}
+	}
+
+	switch cfg.Overlap {
+	case "", "queue":
+		polling.Overlap = "queue"
+	case "skip":
+		polling.Overlap = "skip"
+	default:
+		return nil, fmt.Errorf("invalid polling overlap %q (expected \"queue\" or \"skip\")", cfg.Overlap)
+	}
+
+	if cfg.Workers < 0 {
+		return nil, fmt.Errorf("polling workers must not be negative")
+	}
+	if cfg.Workers > 0 {
+		polling.Workers = cfg.Workers
+	}
+
+	return polling, nil
+}
+
+// Discovery holds the parsed discovery section of the server config
+type Discovery struct {
+	Enabled    bool
+	Interval   time.Duration
+	Persist    bool
+	BlockRange uint64
+}
+
+// ParseDiscovery parses the discovery section of the server config
+func ParseDiscovery(cfg models.DiscoveryConfig) (*Discovery, error) {
+	discovery := &Discovery{
+		Enabled:    cfg.Enabled,
+		Interval:   DefaultDiscoveryInterval,
+		Persist:    cfg.Persist,
+		BlockRange: DefaultDiscoveryBlockRange,
+	}
+
+	if cfg.Interval != "" {
+		interval, err := time.ParseDuration(cfg.Interval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid discovery interval %q: %v", cfg.Interval, err)
+		}
+		if interval <= 0 {
+			return nil, fmt.Errorf("discovery interval must be positive")
+		}
+		discovery.Interval = interval
+	}
+
+	if cfg.BlockRange > 0 {
+		discovery.BlockRange = cfg.BlockRange
+	}
+
+	return discovery, nil
+}
+
+// Storage holds the parsed storage section of the server config
+type Storage struct {
+	Backend   string
+	Path      string
+	Retention models.RetentionPolicy
+}
+
+// ParseStorage parses the storage section of the server config. The bolt
+// database defaults to a file next to the config file at configPath.
+func ParseStorage(cfg models.StorageConfig, configPath string) (*Storage, error) {
+	retention, err := parseRetention(cfg.History)
+	if err != nil {
+		return nil, err
+	}
+
+	switch cfg.Backend {
+	case "", StorageMemory:
+		return &Storage{Backend: StorageMemory, Retention: retention}, nil
+	case StorageBolt:
+		path := cfg.Path
+		if path == "" {
+			path = filepath.Join(filepath.Dir(configPath), DefaultStorageFile)
+		}
+		return &Storage{Backend: StorageBolt, Path: path, Retention: retention}, nil
+	default:
+		return nil, fmt.Errorf("invalid storage backend %q: expected %q or %q", cfg.Backend, StorageMemory, StorageBolt)
+	}
+}
+
+// parseRetention parses the history retention of the storage section
+func parseRetention(cfg models.HistoryConfig) (models.RetentionPolicy, error) {
+	retention := models.RetentionPolicy{
+		Raw:    DefaultRawRetention,
+		Hourly: DefaultHourlyRetention,
+	}
+
+	for _, r := range []struct {
+		name  string
+		value string
+		dest  *time.Duration
+	}{
+		{"rawRetention", cfg.RawRetention, &retention.Raw},
+		{"hourlyRetention", cfg.HourlyRetention, &retention.Hourly},
+		{"dailyRetention", cfg.DailyRetention, &retention.Daily},
+	} {
+		if r.value == "" {
+			continue
+		}
+		d, err := time.ParseDuration(r.value)
+		if err != nil {
+			return retention, fmt.Errorf("invalid storage history %s %q: %v", r.name, r.value, err)
+		}
+		if d < 0 {
+			return retention, fmt.Errorf("storage history %s must not be negative", r.name)
+		}
+		*r.dest = d
+	}
+	return retention, nil
+}
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"dill-monitor/internal/models"
+	"os"
+	"sync"
+	"time"
+)
+
+// Store holds the address config of a running server and replaces it when
+// the file is reloaded
+type Store struct {
+	path string
+
+	mu  sync.RWMutex
+	cfg *Config
+	// modTime and size identify the file version that was loaded last
+	modTime time.Time
+	size    int64
+}
+
+// NewStore loads the config at path
+func NewStore(path string) (*Store, error) {
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	store := &Store{path: path, cfg: cfg}
+	store.modTime, store.size = fileVersion(path)
+	return store, nil
+}
+
+// Path returns the path of the config file
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Addresses returns a copy of the configured addresses
+func (s *Store) Addresses() []models.Address {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]models.Address(nil), s.cfg.ListAddresses()...)
+}
+
+// Document returns the unified document of the config, or nil for a legacy
+// address file. Reloads only apply its addresses; the other sections are read
+// at startup.
+func (s *Store) Document() *Document {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.cfg.document
+}
+
+// Changed reports whether the file was modified since it was loaded or saved
+func (s *Store) Changed() bool {
+	modTime, size := fileVersion(s.path)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return !modTime.Equal(s.modTime) || size != s.size
+}
+
+// Reload loads the file and swaps it in if it passes validate,
+// which may be nil. It returns the addresses that are no longer configured.
+// On error the current config is kept.
+func (s *Store) Reload(validate func(cfg *Config) error) ([]models.Address, error) {
+	// 검증 실패 시 같은 파일을 반복해서 다시 읽지 않도록 먼저 버전을 기록
+	modTime, size := fileVersion(s.path)
+
+	cfg, err := LoadConfig(s.path)
+	if err == nil && validate != nil {
+		err = validate(cfg)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.modTime, s.size = modTime, size
+	if err != nil {
+		return nil, err
+	}
+
+	removed := removedAddresses(s.cfg.ListAddresses(), cfg.ListAddresses())
+	s.cfg = cfg
+	return removed, nil
+}
+
+// Update replaces the configured addresses with the result of fn, which is
+// called with a copy of the current ones, and saves the file if save is set.
+// The result is validated first; if it is invalid the current config is kept.
+func (s *Store) Update(fn func(addresses []models.Address) []models.Address, save bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cfg := &Config{
+		Addresses: fn(append([]models.Address(nil), s.cfg.ListAddresses()...)),
+		document:  s.cfg.document,
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if save {
+		if err := SaveConfig(s.path, cfg); err != nil {
+			return err
+		}
+		// 직접 저장한 변경은 다시 읽지 않음
+		s.modTime, s.size = fileVersion(s.path)
+	}
+
+	s.cfg = cfg
+	return nil
+}
+
+// removedAddresses returns the old addresses that are not in the new config.
+// An address whose label or network changed is still configured and left to
+// BalanceService.Reconcile, which moves its stored balance to the new labels.
+func removedAddresses(old, new []models.Address) []models.Address {
+	current := make(map[string]bool)
+	for _, addr := range new {
+		current[addr.Address] = true
+	}
+
+	var removed []models.Address
+	for _, addr := range old {
+		if !current[addr.Address] {
+			removed = append(removed, addr)
+		}
+	}
+	return removed
+}
+
+// fileVersion returns the modification time and size of a file, or zero values if it cannot be read
+func fileVersion(path string) (time.Time, int64) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, 0
+	}
+	return info.ModTime(), info.Size()
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"dill-monitor/internal/models"
+	"os"
+	"testing"
+)
+
+func TestStoreUpdate(t *testing.T) {
+	path := writeConfig(t, "config.json", `{"addresses": [{"label": "a", "address": "`+lowerAddress+`"}]}`)
+	store, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 잘못된 변경은 저장하지 않고 현재 설정을 유지
+	err = store.Update(func(addresses []models.Address) []models.Address {
+		addresses[0].ValidatorAddresses = []string{testPubkey, testPubkey}
+		return addresses
+	}, true)
+	if err == nil {
+		t.Fatal("Update succeeded, want the duplicate validator rejected")
+	}
+	if after, _ := os.ReadFile(path); string(after) != string(before) {
+		t.Errorf("config file changed to %s", after)
+	}
+	if addresses := store.Addresses(); len(addresses[0].ValidatorAddresses) != 0 {
+		t.Errorf("addresses = %+v, want the current config kept", addresses)
+	}
+
+	err = store.Update(func(addresses []models.Address) []models.Address {
+		addresses[0].ValidatorAddresses = []string{testPubkey}
+		return addresses
+	}, true)
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if store.Changed() {
+		t.Error("Changed = true after saving, want the saved file not to be reloaded")
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if len(cfg.Addresses[0].ValidatorAddresses) != 1 {
+		t.Errorf("saved addresses = %+v, want the validator", cfg.Addresses)
+	}
+}
@@ -0,0 +1,247 @@
+package config
+
+import (
+	"dill-monitor/internal/models"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// pubkeyLength is the length of a BLS public key in bytes
+const pubkeyLength = 48
+
+// ValidationError lists every problem found in a config, each prefixed with
+// the JSON path of the offending value
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Problems) == 1 {
+		return e.Problems[0]
+	}
+	return fmt.Sprintf("%d problems:\n  %s", len(e.Problems), strings.Join(e.Problems, "\n  "))
+}
+
+// Validate checks the addresses of the config and reports all problems at once
+func (c *Config) Validate() error {
+	return validationError(c.problems())
+}
+
+// problems returns the problems of the configured addresses
+func (c *Config) problems() []string {
+	var problems []string
+	addresses := make(map[string]string)
+	labels := make(map[string]string)
+
+	for i, addr := range c.Addresses {
+		path := fmt.Sprintf("addresses[%d]", i)
+
+		switch err := checkAddress(addr.Address); {
+		case err != nil:
+			problems = append(problems, fmt.Sprintf("%s.address: %v", path, err))
+		case addresses[strings.ToLower(addr.Address)] != "":
+			problems = append(problems, fmt.Sprintf("%s.address: duplicate address %s, already used by %s",
+				path, addr.Address, addresses[strings.ToLower(addr.Address)]))
+		default:
+			addresses[strings.ToLower(addr.Address)] = path
+		}
+
+		if addr.Label != "" {
+			if other, exists := labels[addr.Label]; exists {
+				problems = append(problems, fmt.Sprintf("%s.label: duplicate label %q, already used by %s", path, addr.Label, other))
+			} else {
+				labels[addr.Label] = path
+			}
+		}
+
+		// validator_address와 validator_addresses에 걸쳐 같은 검증자가 두 번 설정되었는지 확인.
+		// 여러 주소가 공유하는 검증자는 허용 (요약 메트릭에서 한 번만 집계)
+		pubkeys := make(map[string]string)
+		addPubkey := func(field, pubkey string) {
+			if err := checkPubkey(pubkey); err != nil {
+				problems = append(problems, fmt.Sprintf("%s.%s: %v", path, field, err))
+				return
+			}
+			key := models.NormalizePubkey(pubkey)
+			if other, exists := pubkeys[key]; exists {
+				problems = append(problems, fmt.Sprintf("%s.%s: duplicate validator, already configured at %s", path, field, other))
+				return
+			}
+			pubkeys[key] = path + "." + field
+		}
+		if addr.ValidatorAddress != "" {
+			addPubkey("validator_address", addr.ValidatorAddress)
+		}
+		for j, pubkey := range addr.ValidatorAddresses {
+			addPubkey(fmt.Sprintf("validator_addresses[%d]", j), pubkey)
+		}
+
+		for j, index := range addr.ValidatorIndices {
+			if _, err := strconv.ParseUint(index, 10, 64); err != nil {
+				problems = append(problems, fmt.Sprintf("%s.validator_indices[%d]: invalid validator index %q", path, j, index))
+			}
+		}
+
+		if err := checkInterval(addr.Interval); err != nil {
+			problems = append(problems, fmt.Sprintf("%s.interval: %v", path, err))
+		}
+	}
+	return problems
+}
+
+// checkAddress validates a 0x prefixed 20 byte hex address. Mixed case
+// addresses must carry a valid EIP-55 checksum.
+func checkAddress(address string) error {
+	if address == "" {
+		return fmt.Errorf("address is required")
+	}
+	if !strings.HasPrefix(address, "0x") || !common.IsHexAddress(address) {
+		return fmt.Errorf("invalid address %q: expected 0x followed by 40 hex characters", address)
+	}
+
+	// 모두 소문자나 대문자인 주소는 체크섬이 없는 것으로 간주
+	digits := address[2:]
+	if digits == strings.ToLower(digits) || digits == strings.ToUpper(digits) {
+		return nil
+	}
+	if checksummed := common.HexToAddress(address).Hex(); checksummed != address {
+		return fmt.Errorf("invalid EIP-55 checksum in %q, expected %s", address, checksummed)
+	}
+	return nil
+}
+
+// checkPubkey validates a hex encoded BLS public key with optional 0x prefix
+func checkPubkey(pubkey string) error {
+	raw, err := hex.DecodeString(strings.TrimPrefix(pubkey, "0x"))
+	if err != nil {
+		return fmt.Errorf("invalid validator pubkey %q: not hex encoded", pubkey)
+	}
+	if len(raw) != pubkeyLength {
+		return fmt.Errorf("invalid validator pubkey %q: expected %d bytes, got %d", pubkey, pubkeyLength, len(raw))
+	}
+	return nil
+}
+
+// checkInterval validates a polling interval override
+func checkInterval(interval string) error {
+	if interval == "" || interval == "epoch" {
+		return nil
+	}
+	d, err := time.ParseDuration(interval)
+	if err != nil {
+		return fmt.Errorf("invalid interval %q: expected a duration such as \"15m\" or \"epoch\"", interval)
+	}
+	if d <= 0 {
+		return fmt.Errorf("interval %q must be positive", interval)
+	}
+	return nil
+}
+
+// unknownFields returns the JSON paths of the object keys in data that do not
+// correspond to a field of t, matching keys like encoding/json does. Map
+// values are checked against the element type under their key.
+func unknownFields(path string, data json.RawMessage, t reflect.Type) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(data, &object); err != nil {
+			return nil
+		}
+
+		var problems []string
+		for _, key := range sortedKeys(object) {
+			field, ok := jsonField(t, key)
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s: unknown field", joinPath(path, key)))
+				continue
+			}
+			problems = append(problems, unknownFields(joinPath(path, key), object[key], field.Type)...)
+		}
+		return problems
+
+	case reflect.Map:
+		// networks.profiles.<name>처럼 이름으로 선언된 항목도 필드를 검사
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(data, &object); err != nil {
+			return nil
+		}
+
+		var problems []string
+		for _, key := range sortedKeys(object) {
+			problems = append(problems, unknownFields(joinPath(path, key), object[key], t.Elem())...)
+		}
+		return problems
+
+	case reflect.Slice, reflect.Array:
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil
+		}
+
+		var problems []string
+		for i, item := range items {
+			problems = append(problems, unknownFields(fmt.Sprintf("%s[%d]", path, i), item, t.Elem())...)
+		}
+		return problems
+	}
+	return nil
+}
+
+// jsonField returns the field of t decoded from an object key
+func jsonField(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if strings.EqualFold(name, key) {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// sortedKeys returns the keys of an object in a stable order
+func sortedKeys(object map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// joinPath appends an object key to a JSON path
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// validationError returns a ValidationError for problems, or nil if there are none
+func validationError(problems []string) error {
+	if len(problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: problems}
+}
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	checksummedAddress = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	lowerAddress       = "0xfb6916095ca1df60bb79ce92ce3ea74c37c5d359"
+	testPubkey         = "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+)
+
+// writeConfig writes a config file with the given name and contents to a temporary directory
+func writeConfig(t *testing.T, name, contents string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfigValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   string
+		problems []string
+	}{
+		{
+			name:   "valid",
+			config: `{"addresses": [{"label": "a", "address": "` + checksummedAddress + `", "validator_address": "` + testPubkey + `"}, {"label": "b", "address": "` + lowerAddress + `", "validator_indices": ["42"], "interval": "epoch"}]}`,
+		},
+		{
+			name:     "unknown field",
+			config:   `{"addresses": [{"label": "a", "address": "` + lowerAddress + `", "index": 42}]}`,
+			problems: []string{"addresses[0].index: unknown field"},
+		},
+		{
+			name:     "unknown field of a network profile",
+			config:   `{"version": 1, "networks": {"profiles": {"andes": {"explorerUrl": "https://explorer", "stakerURL": "https://staker", "genesis": 1}}}, "addresses": []}`,
+			problems: []string{"networks.profiles.andes.genesis: unknown field"},
+		},
+		{
+			name:     "invalid address",
+			config:   `{"addresses": [{"address": "0x1234"}]}`,
+			problems: []string{`addresses[0].address: invalid address "0x1234": expected 0x followed by 40 hex characters`},
+		},
+		{
+			name:     "invalid checksum",
+			config:   `{"addresses": [{"address": "0x5aaeb6053F3E94C9b9A09f33669435E7Ef1BeAed"}]}`,
+			problems: []string{`addresses[0].address: invalid EIP-55 checksum in "0x5aaeb6053F3E94C9b9A09f33669435E7Ef1BeAed", expected ` + checksummedAddress},
+		},
+		{
+			name:     "invalid pubkey",
+			config:   `{"addresses": [{"address": "` + lowerAddress + `", "validator_address": "0xabcd"}]}`,
+			problems: []string{`addresses[0].validator_address: invalid validator pubkey "0xabcd": expected 48 bytes, got 2`},
+		},
+		{
+			name: "duplicates",
+			config: `{"addresses": [
+				{"label": "a", "address": "` + checksummedAddress + `", "validator_address": "` + testPubkey + `"},
+				{"label": "a", "address": "` + strings.ToLower(checksummedAddress) + `", "validator_address": "` + testPubkey + `", "validator_addresses": ["` + strings.TrimPrefix(testPubkey, "0x") + `"]}
+			]}`,
+			problems: []string{
+				"addresses[1].address: duplicate address " + strings.ToLower(checksummedAddress) + ", already used by addresses[0]",
+				`addresses[1].label: duplicate label "a", already used by addresses[0]`,
+				"addresses[1].validator_addresses[0]: duplicate validator, already configured at addresses[1].validator_address",
+			},
+		},
+		{
+			name: "validator shared by several addresses",
+			config: `{"addresses": [
+				{"label": "a", "address": "` + checksummedAddress + `", "validator_address": "` + testPubkey + `"},
+				{"label": "b", "address": "` + lowerAddress + `", "validator_addresses": ["` + testPubkey + `"]}
+			]}`,
+		},
+		{
+			name:   "every problem at once",
+			config: `{"addresses": [{"address": "", "validator_indices": ["x"], "interval": "-1m", "extra": true}]}`,
+			problems: []string{
+				"addresses[0].extra: unknown field",
+				"addresses[0].address: address is required",
+				`addresses[0].validator_indices[0]: invalid validator index "x"`,
+				`addresses[0].interval: interval "-1m" must be positive`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeConfig(t, "config.json", tt.config)
+			_, err := LoadConfig(path)
+			if tt.problems == nil {
+				if err != nil {
+					t.Fatalf("LoadConfig: %v", err)
+				}
+				return
+			}
+
+			want := "invalid config file " + path + ": " + (&ValidationError{Problems: tt.problems}).Error()
+			if err == nil || err.Error() != want {
+				t.Errorf("LoadConfig error =\n%v\nwant\n%s", err, want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigTypeError(t *testing.T) {
+	_, err := LoadConfig(writeConfig(t, "config.json", `{"addresses": [{"address": "`+lowerAddress+`", "validator_indices": [42]}]}`))
+	if err == nil || !strings.Contains(err.Error(), "addresses[0].validator_indices[0]: expected string, got JSON number") {
+		t.Fatalf("LoadConfig error = %v, want the path of the mistyped value", err)
+	}
+}
+
+func TestCheckInterval(t *testing.T) {
+	tests := []struct {
+		interval string
+		valid    bool
+	}{
+		{"", true},
+		{"epoch", true},
+		{"15m", true},
+		{"0s", false},
+		{"-5m", false},
+		{"daily", false},
+	}
+
+	for _, tt := range tests {
+		if err := checkInterval(tt.interval); (err == nil) != tt.valid {
+			t.Errorf("checkInterval(%q) = %v, want valid %v", tt.interval, err, tt.valid)
+		}
+	}
+}
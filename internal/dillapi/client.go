@@ -0,0 +1,268 @@
+package dillapi
+
+import (
+	"bytes"
+	"context"
+	"dill-monitor/internal/models"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Endpoint names reported in metrics
+const (
+	EndpointBalance         = "balance"
+	EndpointStaker          = "staker"
+	EndpointValidatorList   = "validator_list"
+	EndpointValidatorDetail = "validator_detail"
+)
+
+// HTTPClient implements DillAPI against the explorer tRPC and staker HTTP APIs
+type HTTPClient struct {
+	explorerURL string
+	stakerURL   string
+	httpClient  *http.Client
+	opts        Options
+	budget      *retryBudget
+}
+
+// NewHTTPClient creates a new client for the given explorer and staker endpoints
+func NewHTTPClient(explorerURL, stakerURL string, opts Options) *HTTPClient {
+	opts = opts.withDefaults()
+	return &HTTPClient{
+		explorerURL: strings.TrimSuffix(explorerURL, "/"),
+		stakerURL:   stakerURL,
+		httpClient:  &http.Client{},
+		opts:        opts,
+		budget:      newRetryBudget(opts.RetryBudget, retryBudgetBurst),
+	}
+}
+
+// GetBalance implements DillAPI.GetBalance
+func (c *HTTPClient) GetBalance(ctx context.Context, address string) (string, error) {
+	input := fmt.Sprintf(`{"json":{"address":"%s"}}`, address)
+	body, err := c.get(ctx, EndpointBalance, c.trpcURL("stats.getBalance", input))
+	if err != nil {
+		return "", err
+	}
+
+	var response struct {
+		Result struct {
+			Data struct {
+				JSON struct {
+					Balance string `json:"balance"`
+				} `json:"json"`
+			} `json:"data"`
+		} `json:"result"`
+	}
+
+	if err := c.decode(EndpointBalance, "GET", body, &response); err != nil {
+		return "", err
+	}
+
+	return response.Result.Data.JSON.Balance, nil
+}
+
+// GetStakerInfo implements DillAPI.GetStakerInfo
+func (c *HTTPClient) GetStakerInfo(ctx context.Context, address string) (*models.StakerResponse, error) {
+	requestBody := fmt.Sprintf(`{"Action":"GetUserInfo","Address":"%s"}`, address)
+
+	// GetUserInfo는 조회 전용이므로 POST이지만 재시도해도 안전함
+	body, status, err := c.do(ctx, EndpointStaker, true, func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", c.stakerURL+"?Action=GetUserInfo", strings.NewReader(requestBody))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 스테이킹 이력이 없는 주소는 오류가 아니므로 기록하지 않고 0으로 처리
+	if isNotStaker(status, body) {
+		return &models.StakerResponse{}, nil
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("invalid HTTP status code %d for staker %s", status, address)
+	}
+
+	var stakerResponse models.StakerResponse
+	if err := c.decode(EndpointStaker, "POST", body, &stakerResponse); err != nil {
+		return nil, fmt.Errorf("error parsing staker info of %s: %v", address, err)
+	}
+
+	return &stakerResponse, nil
+}
+
+// GetValidatorInfo implements DillAPI.GetValidatorInfo
+func (c *HTTPClient) GetValidatorInfo(ctx context.Context, pubkey string) (*models.ValidatorInfo, error) {
+	input, err := json.Marshal(newValidatorListInput(pubkey))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.get(ctx, EndpointValidatorList, c.trpcURL("stats.getAllValidators", string(input)))
+	if err != nil {
+		return nil, err
+	}
+
+	var response validatorListResult
+	if err := c.decode(EndpointValidatorList, "GET", body, &response); err != nil {
+		return nil, err
+	}
+
+	return matchValidator(pubkey, response.Result.Data.JSON.Data), nil
+}
+
+// GetValidatorDetails implements DillAPI.GetValidatorDetails
+func (c *HTTPClient) GetValidatorDetails(ctx context.Context, validatorIdx string) (*models.ValidatorDetailResponse, error) {
+	// Use UTC time to avoid timezone issues
+	now := time.Now().UTC()
+	endTime := now.UnixNano() / int64(time.Millisecond)
+	startTime := now.Add(-24*time.Hour).UnixNano() / int64(time.Millisecond)
+
+	input := fmt.Sprintf(`{"json":{"item":"only to meet the parameter requirements of tRPC","validatorKey":"%s","validatorIdx":"%s","validatorIsStr":false,"startTime":%d,"endTime":%d}}`,
+		validatorIdx, validatorIdx, startTime, endTime)
+
+	requestURL := c.trpcURL("stats.getValidatorDetailByKeyOrIdx", input)
+	body, status, err := c.do(ctx, EndpointValidatorDetail, true, func(ctx context.Context) (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, "GET", requestURL, nil)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error making HTTP request for validator details %s: %v", validatorIdx, err)
+	}
+
+	// HTTP 상태 코드 확인
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("invalid HTTP status code %d for validator %s", status, validatorIdx)
+	}
+
+	var detailResponse models.ValidatorDetailResponse
+	if err := c.decode(EndpointValidatorDetail, "GET", body, &detailResponse); err != nil {
+		return nil, fmt.Errorf("error parsing response for validator details %s: %v", validatorIdx, err)
+	}
+
+	return &detailResponse, nil
+}
+
+// isNotStaker reports whether the staker API answered that an address has
+// never staked: either 404, or 200 with an empty or null body. Other bodies
+// that are not valid JSON are decode failures.
+func isNotStaker(status int, body []byte) bool {
+	switch status {
+	case http.StatusNotFound:
+		return true
+	case http.StatusOK:
+		trimmed := bytes.TrimSpace(body)
+		return len(trimmed) == 0 || string(trimmed) == "null"
+	default:
+		return false
+	}
+}
+
+// trpcURL builds the URL of a tRPC procedure call with a URL encoded input
+func (c *HTTPClient) trpcURL(procedure, input string) string {
+	return fmt.Sprintf("%s/%s?input=%s", c.explorerURL, procedure, url.QueryEscape(input))
+}
+
+// get performs an idempotent GET request and returns the response body. A
+// non-2xx response is returned as a *StatusError, since tRPC error bodies
+// would otherwise decode to empty results.
+func (c *HTTPClient) get(ctx context.Context, endpoint, requestURL string) ([]byte, error) {
+	body, status, err := c.do(ctx, endpoint, true, func(ctx context.Context) (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, "GET", requestURL, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if status < 200 || status >= 300 {
+		return nil, &StatusError{Endpoint: endpoint, Status: status}
+	}
+	return body, nil
+}
+
+// do sends the request built by newRequest and returns the response body and
+// status code. Transport errors, timeouts and 5xx/429 responses of retryable
+// requests are retried with jittered exponential backoff while the retry budget
+// allows it. The request is rebuilt for every attempt so that bodies can be resent.
+func (c *HTTPClient) do(ctx context.Context, endpoint string, retryable bool, newRequest func(ctx context.Context) (*http.Request, error)) ([]byte, int, error) {
+	c.budget.deposit()
+
+	for attempt := 0; ; attempt++ {
+		body, status, err := c.attempt(ctx, endpoint, newRequest)
+		if err == nil && !isRetryableStatus(status) {
+			return body, status, nil
+		}
+		if err == nil {
+			err = fmt.Errorf("upstream returned HTTP %d", status)
+		}
+
+		// 호출자가 취소했거나 재시도 불가능한 경우 즉시 실패
+		if !retryable || ctx.Err() != nil || attempt >= c.opts.MaxRetries || !c.budget.withdraw() {
+			c.recordFailure(endpoint)
+			return body, status, err
+		}
+
+		c.recordRetry(endpoint)
+		if err := sleep(ctx, backoff(attempt, c.opts.InitialBackoff, c.opts.MaxBackoff)); err != nil {
+			c.recordFailure(endpoint)
+			return nil, 0, err
+		}
+	}
+}
+
+// attempt waits for the rate limiter of the upstream host, performs a single
+// request bounded by the per-request timeout and records its status, latency
+// and error type
+func (c *HTTPClient) attempt(ctx context.Context, endpoint string, newRequest func(ctx context.Context) (*http.Request, error)) ([]byte, int, error) {
+	req, err := newRequest(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// 호스트별 rate limit 대기는 요청 타임아웃에 포함하지 않음
+	wait, err := c.opts.RateLimiters.Wait(ctx, req.URL.Host)
+	c.recordRateLimitWait(req.URL.Host, wait)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, c.opts.Timeout)
+	defer cancel()
+	req = req.WithContext(attemptCtx)
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.recordRequest(endpoint, req.Method, 0, time.Since(start))
+		c.recordError(endpoint, req.Method, classifyError(err))
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	c.recordRequest(endpoint, req.Method, resp.StatusCode, time.Since(start))
+	if err != nil {
+		c.recordError(endpoint, req.Method, classifyError(err))
+		return nil, resp.StatusCode, err
+	}
+
+	// 비스테이커에 대한 staker API의 404는 정상 응답
+	notStaker := endpoint == EndpointStaker && resp.StatusCode == http.StatusNotFound
+	if errorType := classifyStatus(resp.StatusCode); errorType != "" && !notStaker {
+		c.recordError(endpoint, req.Method, errorType)
+	}
+
+	return body, resp.StatusCode, nil
+}
+
+// isRetryableStatus reports whether a response status indicates a transient failure
+func isRetryableStatus(status int) bool {
+	return status >= 500 || status == http.StatusTooManyRequests
+}
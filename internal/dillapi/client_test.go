@@ -0,0 +1,104 @@
+package dillapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newTestServer returns a client whose explorer and staker endpoints answer
+// every request with status and body
+func newTestServer(t *testing.T, status int, body string) (*HTTPClient, *testRecorder) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	recorder := &testRecorder{}
+	client := NewHTTPClient(server.URL, server.URL, Options{
+		MaxRetries:     -1,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		Recorder:       recorder,
+	})
+	return client, recorder
+}
+
+func TestGetStakerInfo(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   int64
+		// errors are the error types recorded; err is whether the call fails
+		errors []string
+		err    bool
+	}{
+		{"staker", 200, `{"stakedAmount": 3600}`, 3600, nil, false},
+		{"not found", 404, `{"error": "not found"}`, 0, nil, false},
+		{"empty body", 200, "", 0, nil, false},
+		{"null body", 200, " null\n", 0, nil, false},
+		{"invalid JSON", 200, "<html>", 0, []string{ErrorTypeInvalidJSON}, true},
+		{"bad request", 400, `{"error": "bad address"}`, 0, []string{ErrorTypeHTTP4xx}, true},
+		{"server error", 500, "", 0, []string{ErrorTypeHTTP5xx}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, recorder := newTestServer(t, tt.status, tt.body)
+
+			staker, err := client.GetStakerInfo(context.Background(), "0x1")
+			if (err != nil) != tt.err {
+				t.Fatalf("GetStakerInfo error = %v, want error %v", err, tt.err)
+			}
+			if err == nil && staker.StakedAmount != tt.want {
+				t.Errorf("staked amount = %d, want %d", staker.StakedAmount, tt.want)
+			}
+			if !reflect.DeepEqual(recorder.errors, tt.errors) {
+				t.Errorf("errors = %v, want %v", recorder.errors, tt.errors)
+			}
+		})
+	}
+}
+
+func TestGetStatusError(t *testing.T) {
+	// tRPC 오류 응답도 JSON이므로 상태 코드를 확인하지 않으면 빈 결과로 해석됨
+	const errorBody = `{"error": {"json": {"message": "BAD_REQUEST", "code": -32600}}}`
+
+	tests := []struct {
+		name     string
+		endpoint string
+		call     func(client *HTTPClient) error
+	}{
+		{"balance", EndpointBalance, func(client *HTTPClient) error {
+			_, err := client.GetBalance(context.Background(), "0x1")
+			return err
+		}},
+		{"validator info", EndpointValidatorList, func(client *HTTPClient) error {
+			_, err := client.GetValidatorInfo(context.Background(), "0xaa")
+			return err
+		}},
+		{"validator batch", EndpointValidatorList, func(client *HTTPClient) error {
+			_, err := client.GetValidatorsByPubkeys(context.Background(), []string{"0xaa", "0xbb"})
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestServer(t, http.StatusBadRequest, errorBody)
+
+			var statusErr *StatusError
+			if err := tt.call(client); !errors.As(err, &statusErr) || statusErr.Status != http.StatusBadRequest || statusErr.Endpoint != tt.endpoint {
+				t.Errorf("error = %v, want a status error of %s", err, tt.endpoint)
+			}
+		})
+	}
+}
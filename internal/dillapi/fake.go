@@ -0,0 +1,130 @@
+package dillapi
+
+import (
+	"context"
+	"dill-monitor/internal/models"
+	"fmt"
+	"sync"
+)
+
+// FakeClient is an in-memory DillAPI implementation for tests and local runs.
+// Responses are keyed by address, pubkey or validator index; an entry in Errors
+// keyed by the same value makes the corresponding call fail.
+type FakeClient struct {
+	mu sync.RWMutex
+
+	Balances   map[string]string
+	Stakers    map[string]*models.StakerResponse
+	Validators map[string]*models.ValidatorInfo
+	Details    map[string]*models.ValidatorDetailResponse
+	Errors     map[string]error
+
+	calls map[string]int
+}
+
+// NewFakeClient creates an empty fake client
+func NewFakeClient() *FakeClient {
+	return &FakeClient{
+		Balances:   make(map[string]string),
+		Stakers:    make(map[string]*models.StakerResponse),
+		Validators: make(map[string]*models.ValidatorInfo),
+		Details:    make(map[string]*models.ValidatorDetailResponse),
+		Errors:     make(map[string]error),
+		calls:      make(map[string]int),
+	}
+}
+
+// GetBalance implements DillAPI.GetBalance
+func (f *FakeClient) GetBalance(ctx context.Context, address string) (string, error) {
+	if err := f.record(ctx, "GetBalance", address); err != nil {
+		return "", err
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	balance, exists := f.Balances[address]
+	if !exists {
+		return "0", nil
+	}
+	return balance, nil
+}
+
+// GetStakerInfo implements DillAPI.GetStakerInfo
+func (f *FakeClient) GetStakerInfo(ctx context.Context, address string) (*models.StakerResponse, error) {
+	if err := f.record(ctx, "GetStakerInfo", address); err != nil {
+		return nil, err
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if staker, exists := f.Stakers[address]; exists {
+		return staker, nil
+	}
+	return &models.StakerResponse{}, nil
+}
+
+// GetValidatorInfo implements DillAPI.GetValidatorInfo
+func (f *FakeClient) GetValidatorInfo(ctx context.Context, pubkey string) (*models.ValidatorInfo, error) {
+	if err := f.record(ctx, "GetValidatorInfo", pubkey); err != nil {
+		return nil, err
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return f.Validators[pubkey], nil
+}
+
+// GetValidatorsByPubkeys implements DillAPI.GetValidatorsByPubkeys
+func (f *FakeClient) GetValidatorsByPubkeys(ctx context.Context, pubkeys []string) (map[string]*models.ValidatorInfo, error) {
+	validators := make(map[string]*models.ValidatorInfo, len(pubkeys))
+	for _, pubkey := range pubkeys {
+		if err := f.record(ctx, "GetValidatorsByPubkeys", pubkey); err != nil {
+			return validators, err
+		}
+
+		f.mu.RLock()
+		if validator, exists := f.Validators[pubkey]; exists {
+			validators[pubkey] = validator
+		}
+		f.mu.RUnlock()
+	}
+	return validators, nil
+}
+
+// GetValidatorDetails implements DillAPI.GetValidatorDetails
+func (f *FakeClient) GetValidatorDetails(ctx context.Context, validatorIdx string) (*models.ValidatorDetailResponse, error) {
+	if err := f.record(ctx, "GetValidatorDetails", validatorIdx); err != nil {
+		return nil, err
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if details, exists := f.Details[validatorIdx]; exists {
+		return details, nil
+	}
+	return nil, fmt.Errorf("no details for validator %s", validatorIdx)
+}
+
+// Calls returns how many times a method was called with the given key
+func (f *FakeClient) Calls(method, key string) int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.calls[method+":"+key]
+}
+
+// record counts the call and returns the configured error for the key, if any
+func (f *FakeClient) record(ctx context.Context, method, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls[method+":"+key]++
+	return f.Errors[key]
+}
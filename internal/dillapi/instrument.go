@@ -0,0 +1,152 @@
+package dillapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"time"
+)
+
+// Error types reported in metrics
+const (
+	ErrorTypeTimeout     = "timeout"
+	ErrorTypeDNS         = "dns"
+	ErrorTypeConnection  = "connection"
+	ErrorTypeCanceled    = "canceled"
+	ErrorTypeDecode      = "decode"
+	ErrorTypeHTTP4xx     = "http_4xx"
+	ErrorTypeHTTP5xx     = "http_5xx"
+	ErrorTypeEmptyBody   = "empty_body"
+	ErrorTypeInvalidJSON = "invalid_json"
+)
+
+// StatusError reports a response with a non-2xx HTTP status
+type StatusError struct {
+	Endpoint string
+	Status   int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("invalid HTTP status code %d from %s", e.Status, e.Endpoint)
+}
+
+// Recorder receives instrumentation events from the HTTP client
+type Recorder interface {
+	// RecordAPIMetric records one HTTP attempt; status is 0 when no response was received
+	RecordAPIMetric(network, endpoint, method string, duration float64, status int) error
+	// RecordAPIError records a classified failure of an attempt or of its response body
+	RecordAPIError(network, endpoint, method, errorType string) error
+	RecordUpstreamRetry(network, endpoint string) error
+	RecordUpstreamFailure(network, endpoint string) error
+	// RecordRateLimitWait records the time a request waited for the rate limiter of its host
+	RecordRateLimitWait(host string, wait float64) error
+}
+
+// classifyError maps a transport error to an error type
+func classifyError(err error) string {
+	var dnsErr *net.DNSError
+	var netErr net.Error
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return ErrorTypeCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorTypeTimeout
+	case errors.As(err, &dnsErr):
+		return ErrorTypeDNS
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return ErrorTypeTimeout
+	default:
+		return ErrorTypeConnection
+	}
+}
+
+// classifyStatus maps an HTTP status to an error type, or "" for success
+func classifyStatus(status int) string {
+	switch {
+	case status >= 500:
+		return ErrorTypeHTTP5xx
+	case status >= 400:
+		return ErrorTypeHTTP4xx
+	default:
+		return ""
+	}
+}
+
+// decode parses a JSON response body into v, recording why it could not be parsed
+func (c *HTTPClient) decode(endpoint, method string, body []byte, v interface{}) error {
+	if len(body) == 0 {
+		c.recordError(endpoint, method, ErrorTypeEmptyBody)
+		return fmt.Errorf("empty response body")
+	}
+
+	if !json.Valid(body) {
+		c.recordError(endpoint, method, ErrorTypeInvalidJSON)
+		// 유효하지 않은 JSON이면 처음 100자만 로그로 출력
+		invalidJSON := string(body)
+		if len(invalidJSON) > 100 {
+			invalidJSON = invalidJSON[:100] + "..."
+		}
+		return fmt.Errorf("invalid JSON response: %s", invalidJSON)
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		c.recordError(endpoint, method, ErrorTypeDecode)
+		return err
+	}
+
+	return nil
+}
+
+// recordRequest reports the status and latency of an attempt to the recorder, if any
+func (c *HTTPClient) recordRequest(endpoint, method string, status int, duration time.Duration) {
+	if c.opts.Recorder == nil {
+		return
+	}
+	if err := c.opts.Recorder.RecordAPIMetric(c.opts.Network, endpoint, method, duration.Seconds(), status); err != nil {
+		log.Printf("Error recording API metric: %v", err)
+	}
+}
+
+// recordError reports a classified error to the recorder, if any
+func (c *HTTPClient) recordError(endpoint, method, errorType string) {
+	if c.opts.Recorder == nil {
+		return
+	}
+	if err := c.opts.Recorder.RecordAPIError(c.opts.Network, endpoint, method, errorType); err != nil {
+		log.Printf("Error recording API error: %v", err)
+	}
+}
+
+// recordRetry reports a retry to the recorder, if any
+func (c *HTTPClient) recordRetry(endpoint string) {
+	if c.opts.Recorder == nil {
+		return
+	}
+	if err := c.opts.Recorder.RecordUpstreamRetry(c.opts.Network, endpoint); err != nil {
+		log.Printf("Error recording upstream retry: %v", err)
+	}
+}
+
+// recordFailure reports a final failure to the recorder, if any
+func (c *HTTPClient) recordFailure(endpoint string) {
+	if c.opts.Recorder == nil {
+		return
+	}
+	if err := c.opts.Recorder.RecordUpstreamFailure(c.opts.Network, endpoint); err != nil {
+		log.Printf("Error recording upstream failure: %v", err)
+	}
+}
+
+// recordRateLimitWait reports the time spent waiting for a host's rate limiter to the recorder, if any
+func (c *HTTPClient) recordRateLimitWait(host string, wait time.Duration) {
+	if c.opts.Recorder == nil || c.opts.RateLimiters == nil {
+		return
+	}
+	if err := c.opts.Recorder.RecordRateLimitWait(host, wait.Seconds()); err != nil {
+		log.Printf("Error recording rate limit wait: %v", err)
+	}
+}
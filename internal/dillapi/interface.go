@@ -0,0 +1,27 @@
+package dillapi
+
+import (
+	"context"
+	"dill-monitor/internal/models"
+)
+
+// DillAPI defines the upstream calls the balance service depends on
+type DillAPI interface {
+	// GetBalance returns the raw wallet balance of an address in Wei
+	GetBalance(ctx context.Context, address string) (string, error)
+
+	// GetStakerInfo returns the staking summary of an address from the staker API
+	GetStakerInfo(ctx context.Context, address string) (*models.StakerResponse, error)
+
+	// GetValidatorInfo returns the validator registered under a BLS public key,
+	// or nil if the explorer does not know it
+	GetValidatorInfo(ctx context.Context, pubkey string) (*models.ValidatorInfo, error)
+
+	// GetValidatorsByPubkeys looks up several validators at once and returns
+	// those found keyed by the requested pubkey. On error the validators found
+	// before the failure are returned.
+	GetValidatorsByPubkeys(ctx context.Context, pubkeys []string) (map[string]*models.ValidatorInfo, error)
+
+	// GetValidatorDetails returns the income history of a validator for the last 24 hours
+	GetValidatorDetails(ctx context.Context, validatorIdx string) (*models.ValidatorDetailResponse, error)
+}
@@ -0,0 +1,92 @@
+package dillapi
+
+import (
+	"dill-monitor/internal/models"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultTimeout        = 10 * time.Second
+	defaultMaxRetries     = 3
+	defaultInitialBackoff = 500 * time.Millisecond
+	defaultMaxBackoff     = 10 * time.Second
+	defaultRetryBudget    = 0.2
+	// retryBudgetBurst is the number of retries available before any request was made
+	retryBudgetBurst = 10
+)
+
+// Options configures the HTTP client
+type Options struct {
+	// Network is the network name reported with every recorded event
+	Network string
+	// Timeout bounds a single attempt, including reading the response body
+	Timeout time.Duration
+	// MaxRetries is the number of retries after the first attempt; negative disables retries
+	MaxRetries int
+	// InitialBackoff and MaxBackoff bound the jittered exponential delay between attempts
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// RetryBudget is the number of retries earned per request
+	RetryBudget float64
+	// RateLimiters limits the requests sent to every upstream host, it may be nil
+	RateLimiters *RateLimiters
+	// Recorder receives request, error, retry and failure events, it may be nil
+	Recorder Recorder
+}
+
+// OptionsFromConfig converts the upstream section of the server config to client options
+func OptionsFromConfig(network string, cfg models.UpstreamConfig) (Options, error) {
+	opts := Options{
+		Network:     network,
+		MaxRetries:  cfg.MaxRetries,
+		RetryBudget: cfg.RetryBudget,
+	}
+
+	var err error
+	if opts.Timeout, err = parseDuration("timeout", cfg.Timeout); err != nil {
+		return Options{}, err
+	}
+	if opts.InitialBackoff, err = parseDuration("initialBackoff", cfg.InitialBackoff); err != nil {
+		return Options{}, err
+	}
+	if opts.MaxBackoff, err = parseDuration("maxBackoff", cfg.MaxBackoff); err != nil {
+		return Options{}, err
+	}
+
+	return opts, nil
+}
+
+// withDefaults returns a copy of the options with zero values replaced by defaults
+func (o Options) withDefaults() Options {
+	if o.Timeout <= 0 {
+		o.Timeout = defaultTimeout
+	}
+	if o.MaxRetries == 0 {
+		o.MaxRetries = defaultMaxRetries
+	} else if o.MaxRetries < 0 {
+		o.MaxRetries = 0
+	}
+	if o.InitialBackoff <= 0 {
+		o.InitialBackoff = defaultInitialBackoff
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = defaultMaxBackoff
+	}
+	if o.RetryBudget <= 0 {
+		o.RetryBudget = defaultRetryBudget
+	}
+	return o
+}
+
+// parseDuration parses an optional duration setting
+func parseDuration(name, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid upstream %s %q: %v", name, value, err)
+	}
+	return d, nil
+}
@@ -0,0 +1,120 @@
+package dillapi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRateLimit = 10
+	defaultRateBurst = 10
+)
+
+// RateLimiters holds one token bucket per upstream host. A single instance is
+// shared by the clients of every network, so that networks served by the same
+// host, like the staker API, are limited together.
+type RateLimiters struct {
+	mu      sync.Mutex
+	rate    float64
+	burst   int
+	buckets map[string]*tokenBucket
+}
+
+// NewRateLimiters creates per-host limiters allowing rate requests per second
+// with bursts of up to burst requests. Zero values select the defaults and a
+// negative rate disables limiting, in which case nil is returned.
+func NewRateLimiters(rate float64, burst int) *RateLimiters {
+	if rate < 0 {
+		return nil
+	}
+	if rate == 0 {
+		rate = defaultRateLimit
+	}
+	if burst <= 0 {
+		burst = defaultRateBurst
+	}
+	return &RateLimiters{
+		rate:    rate,
+		burst:   burst,
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// Wait blocks until a request to host is allowed or ctx is done and returns
+// the time spent waiting. A nil RateLimiters never waits.
+func (l *RateLimiters) Wait(ctx context.Context, host string) (time.Duration, error) {
+	if l == nil {
+		return 0, nil
+	}
+
+	bucket := l.bucket(host)
+	delay := bucket.reserve(time.Now())
+	if delay <= 0 {
+		return 0, nil
+	}
+
+	if err := sleep(ctx, delay); err != nil {
+		// 취소된 요청의 토큰은 다른 요청이 사용할 수 있도록 반환
+		bucket.refund()
+		return delay, err
+	}
+	return delay, nil
+}
+
+// bucket returns the token bucket of a host, creating it on first use
+func (l *RateLimiters) bucket(host string) *tokenBucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	bucket, exists := l.buckets[host]
+	if !exists {
+		bucket = &tokenBucket{
+			tokens: float64(l.burst),
+			rate:   l.rate,
+			max:    float64(l.burst),
+			last:   time.Now(),
+		}
+		l.buckets[host] = bucket
+	}
+	return bucket
+}
+
+// tokenBucket refills at rate tokens per second up to max. Tokens may go
+// negative, which queues callers behind the requests already waiting.
+type tokenBucket struct {
+	mu     sync.Mutex
+	tokens float64
+	rate   float64
+	max    float64
+	last   time.Time
+}
+
+// reserve takes a token and returns how long the caller must wait before using it
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.max {
+		b.tokens = b.max
+	}
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// refund returns a reserved token that was not used
+func (b *tokenBucket) refund() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens++
+	if b.tokens > b.max {
+		b.tokens = b.max
+	}
+}
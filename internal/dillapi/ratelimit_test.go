@@ -0,0 +1,69 @@
+package dillapi
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketReserve(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	bucket := &tokenBucket{tokens: 2, rate: 10, max: 2, last: start}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Duration
+	}{
+		{"burst", start, 0},
+		{"burst", start, 0},
+		{"empty", start, 100 * time.Millisecond},
+		// 대기 중인 요청 뒤에 줄을 섬
+		{"queued", start, 200 * time.Millisecond},
+		{"refilled", start.Add(time.Second), 0},
+		// 오래 쉬어도 버스트 크기까지만 채워짐
+		{"capped", start.Add(time.Hour), 0},
+		{"capped", start.Add(time.Hour), 0},
+		{"capped", start.Add(time.Hour), 100 * time.Millisecond},
+	}
+
+	for i, tt := range tests {
+		if got := bucket.reserve(tt.now); got != tt.want {
+			t.Errorf("reserve %d (%s) = %s, want %s", i, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRateLimitersWait(t *testing.T) {
+	if limiters := NewRateLimiters(-1, 0); limiters != nil {
+		t.Fatalf("NewRateLimiters(-1) = %+v, want limiting disabled", limiters)
+	}
+	var disabled *RateLimiters
+	if delay, err := disabled.Wait(context.Background(), "api.example.com"); delay != 0 || err != nil {
+		t.Errorf("Wait without limiters = %s, %v, want no wait", delay, err)
+	}
+
+	limiters := NewRateLimiters(20, 1)
+	ctx := context.Background()
+
+	if delay, err := limiters.Wait(ctx, "api.example.com"); delay != 0 || err != nil {
+		t.Fatalf("first Wait = %s, %v, want no wait", delay, err)
+	}
+	// 같은 호스트는 네트워크가 달라도 함께 제한
+	if delay, err := limiters.Wait(ctx, "api.example.com"); delay <= 0 || err != nil {
+		t.Errorf("second Wait = %s, %v, want a wait", delay, err)
+	}
+	if delay, err := limiters.Wait(ctx, "staker.example.com"); delay != 0 || err != nil {
+		t.Errorf("Wait on another host = %s, %v, want no wait", delay, err)
+	}
+
+	// 취소된 요청의 토큰은 반환되어 다음 요청이 더 기다리지 않음
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := limiters.Wait(cancelled, "staker.example.com"); err == nil {
+		t.Fatal("Wait with a cancelled context succeeded, want an error")
+	}
+	if delay, err := limiters.Wait(ctx, "staker.example.com"); delay > 50*time.Millisecond || err != nil {
+		t.Errorf("Wait after a cancelled request = %s, %v, want one token of wait", delay, err)
+	}
+}
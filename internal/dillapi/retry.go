@@ -0,0 +1,72 @@
+package dillapi
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// retryBudget limits retries to a fraction of the requests made, so that an
+// upstream outage does not multiply the load sent to it
+type retryBudget struct {
+	mu     sync.Mutex
+	tokens float64
+	ratio  float64
+	max    float64
+}
+
+// newRetryBudget creates a budget that earns ratio retries per request and
+// never holds more than max retries
+func newRetryBudget(ratio, max float64) *retryBudget {
+	return &retryBudget{
+		tokens: max,
+		ratio:  ratio,
+		max:    max,
+	}
+}
+
+// deposit credits the budget for a request
+func (b *retryBudget) deposit() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens += b.ratio
+	if b.tokens > b.max {
+		b.tokens = b.max
+	}
+}
+
+// withdraw takes one retry from the budget and reports whether it was available
+func (b *retryBudget) withdraw() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// backoff returns the full-jitter exponential delay before the given retry attempt
+func backoff(attempt int, initial, max time.Duration) time.Duration {
+	delay := initial << uint(attempt)
+	if delay <= 0 || delay > max {
+		delay = max
+	}
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
+
+// sleep waits for the given duration or until the context is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,210 @@
+package dillapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testRecorder counts the retries and failures reported by the client
+type testRecorder struct {
+	mu       sync.Mutex
+	retries  int
+	failures int
+	errors   []string
+}
+
+func (r *testRecorder) RecordAPIMetric(network, endpoint, method string, duration float64, status int) error {
+	return nil
+}
+
+func (r *testRecorder) RecordAPIError(network, endpoint, method, errorType string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors = append(r.errors, errorType)
+	return nil
+}
+
+func (r *testRecorder) RecordUpstreamRetry(network, endpoint string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.retries++
+	return nil
+}
+
+func (r *testRecorder) RecordUpstreamFailure(network, endpoint string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failures++
+	return nil
+}
+
+func (r *testRecorder) RecordRateLimitWait(host string, wait float64) error {
+	return nil
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{0, 100 * time.Millisecond},
+		{1, 200 * time.Millisecond},
+		{3, 800 * time.Millisecond},
+		{4, time.Second},
+		// 시프트가 넘쳐도 최대값으로 제한
+		{100, time.Second},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 100; i++ {
+			if d := backoff(tt.attempt, 100*time.Millisecond, time.Second); d < 0 || d > tt.max {
+				t.Fatalf("backoff(%d) = %v, want between 0 and %v", tt.attempt, d, tt.max)
+			}
+		}
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	tests := []struct {
+		name string
+		// drain spends the initial burst before the requests are made
+		drain     bool
+		ratio     float64
+		max       float64
+		deposits  int
+		withdrawn int
+	}{
+		{"burst", false, 0.5, 2, 0, 2},
+		{"earned by requests", true, 0.5, 2, 4, 2},
+		{"capped at max", true, 1, 3, 10, 3},
+		{"fraction of a retry", true, 0.2, 2, 4, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			budget := newRetryBudget(tt.ratio, tt.max)
+			for tt.drain && budget.withdraw() {
+			}
+			for i := 0; i < tt.deposits; i++ {
+				budget.deposit()
+			}
+
+			withdrawn := 0
+			for budget.withdraw() {
+				withdrawn++
+			}
+			if withdrawn != tt.withdrawn {
+				t.Errorf("withdrawn = %d, want %d", withdrawn, tt.withdrawn)
+			}
+		})
+	}
+}
+
+func TestClientRetries(t *testing.T) {
+	tests := []struct {
+		name       string
+		statuses   []int
+		maxRetries int
+		budget     float64
+		attempts   int32
+		retries    int
+		failures   int
+		wantErr    bool
+	}{
+		{"success", []int{200}, 3, 1, 1, 0, 0, false},
+		{"transient 502", []int{502, 502, 200}, 3, 1, 3, 2, 0, false},
+		{"429 is retried", []int{429, 200}, 3, 1, 2, 1, 0, false},
+		{"retries exhausted", []int{503}, 2, 1, 3, 2, 1, true},
+		{"4xx is not retried", []int{400}, 3, 1, 1, 0, 0, true},
+		{"retries disabled", []int{500}, -1, 1, 1, 0, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&attempts, 1)
+				status := tt.statuses[len(tt.statuses)-1]
+				if int(n) <= len(tt.statuses) {
+					status = tt.statuses[n-1]
+				}
+				w.WriteHeader(status)
+				w.Write([]byte(`{"result":{"data":{"json":{"balance":"1"}}}}`))
+			}))
+			defer server.Close()
+
+			recorder := &testRecorder{}
+			client := NewHTTPClient(server.URL, server.URL, Options{
+				MaxRetries:     tt.maxRetries,
+				InitialBackoff: time.Millisecond,
+				MaxBackoff:     time.Millisecond,
+				RetryBudget:    tt.budget,
+				Recorder:       recorder,
+			})
+
+			_, err := client.GetBalance(context.Background(), "0x1")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetBalance error = %v, want error %v", err, tt.wantErr)
+			}
+			if got := atomic.LoadInt32(&attempts); got != tt.attempts {
+				t.Errorf("attempts = %d, want %d", got, tt.attempts)
+			}
+			if recorder.retries != tt.retries || recorder.failures != tt.failures {
+				t.Errorf("retries, failures = %d, %d, want %d, %d", recorder.retries, recorder.failures, tt.retries, tt.failures)
+			}
+		})
+	}
+}
+
+func TestClientTimeoutAndCancel(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	t.Run("timeout", func(t *testing.T) {
+		recorder := &testRecorder{}
+		client := NewHTTPClient(server.URL, server.URL, Options{
+			Timeout:    20 * time.Millisecond,
+			MaxRetries: -1,
+			Recorder:   recorder,
+		})
+
+		if _, err := client.GetBalance(context.Background(), "0x1"); err == nil {
+			t.Fatal("GetBalance succeeded, want a timeout")
+		}
+		if len(recorder.errors) != 1 || recorder.errors[0] != ErrorTypeTimeout {
+			t.Errorf("errors = %v, want [%s]", recorder.errors, ErrorTypeTimeout)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		client := NewHTTPClient(server.URL, server.URL, Options{
+			InitialBackoff: time.Hour,
+			MaxBackoff:     time.Hour,
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err := client.GetBalance(ctx, "0x1")
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("GetBalance error = %v, want %v", err, context.DeadlineExceeded)
+		}
+		// 호출자의 컨텍스트가 끝나면 재시도 대기 없이 반환
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("GetBalance returned after %v, want it to stop with the context", elapsed)
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package dillapi
+
+import (
+	"context"
+	"dill-monitor/internal/models"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// maxValidatorBatch bounds the number of lookups sent in one tRPC batch request
+	maxValidatorBatch = 20
+	// validatorPageSize is the number of validators requested per lookup
+	validatorPageSize = 25
+)
+
+// validatorListInput is the input of a stats.getAllValidators call
+type validatorListInput struct {
+	JSON struct {
+		Page   int    `json:"page"`
+		Limit  int    `json:"limit"`
+		Pubkey string `json:"pubkey"`
+	} `json:"json"`
+}
+
+// validatorListResult is the result of a stats.getAllValidators call
+type validatorListResult struct {
+	Result struct {
+		Data struct {
+			JSON struct {
+				Data []models.ValidatorInfo `json:"data"`
+			} `json:"json"`
+		} `json:"data"`
+	} `json:"result"`
+}
+
+// GetValidatorsByPubkeys implements DillAPI.GetValidatorsByPubkeys. Lookups
+// are sent as tRPC batch requests of up to maxValidatorBatch calls each.
+func (c *HTTPClient) GetValidatorsByPubkeys(ctx context.Context, pubkeys []string) (map[string]*models.ValidatorInfo, error) {
+	validators := make(map[string]*models.ValidatorInfo, len(pubkeys))
+	for start := 0; start < len(pubkeys); start += maxValidatorBatch {
+		end := start + maxValidatorBatch
+		if end > len(pubkeys) {
+			end = len(pubkeys)
+		}
+		if err := c.getValidatorBatch(ctx, pubkeys[start:end], validators); err != nil {
+			return validators, err
+		}
+	}
+	return validators, nil
+}
+
+// getValidatorBatch looks up the given pubkeys in one tRPC batch request and
+// adds the validators found to validators
+func (c *HTTPClient) getValidatorBatch(ctx context.Context, pubkeys []string, validators map[string]*models.ValidatorInfo) error {
+	procedures := make([]string, len(pubkeys))
+	inputs := make(map[string]validatorListInput, len(pubkeys))
+	for i, pubkey := range pubkeys {
+		procedures[i] = "stats.getAllValidators"
+		inputs[strconv.Itoa(i)] = newValidatorListInput(pubkey)
+	}
+
+	input, err := json.Marshal(inputs)
+	if err != nil {
+		return err
+	}
+
+	requestURL := fmt.Sprintf("%s/%s?batch=1&input=%s", c.explorerURL, strings.Join(procedures, ","), url.QueryEscape(string(input)))
+	body, err := c.get(ctx, EndpointValidatorList, requestURL)
+	if err != nil {
+		return err
+	}
+
+	var results []validatorListResult
+	if err := c.decode(EndpointValidatorList, "GET", body, &results); err != nil {
+		return err
+	}
+	if len(results) != len(pubkeys) {
+		c.recordError(EndpointValidatorList, "GET", ErrorTypeDecode)
+		return fmt.Errorf("batch response has %d results for %d lookups", len(results), len(pubkeys))
+	}
+
+	for i, pubkey := range pubkeys {
+		if validator := matchValidator(pubkey, results[i].Result.Data.JSON.Data); validator != nil {
+			validators[pubkey] = validator
+		}
+	}
+	return nil
+}
+
+// newValidatorListInput returns the input of a lookup by pubkey
+func newValidatorListInput(pubkey string) validatorListInput {
+	var input validatorListInput
+	input.JSON.Page = 1
+	input.JSON.Limit = validatorPageSize
+	input.JSON.Pubkey = pubkey
+	return input
+}
+
+// matchValidator returns the validator whose pubkey matches the requested one.
+// The explorer may ignore the pubkey filter and return unrelated validators,
+// so the first result is not trusted blindly.
+func matchValidator(pubkey string, candidates []models.ValidatorInfo) *models.ValidatorInfo {
+	want := models.NormalizePubkey(pubkey)
+	for i := range candidates {
+		if models.NormalizePubkey(candidates[i].Pubkey) == want {
+			return &candidates[i]
+		}
+	}
+	return nil
+}
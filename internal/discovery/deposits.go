@@ -0,0 +1,177 @@
+package discovery
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// confirmations is the number of blocks a deposit must be buried under
+// before it is scanned, so that reorgs do not need to be handled
+const confirmations = 16
+
+// depositEventABI is the DepositEvent of the beacon chain deposit contract
+const depositEventABI = `[{"anonymous":false,"inputs":[
+	{"indexed":false,"name":"pubkey","type":"bytes"},
+	{"indexed":false,"name":"withdrawal_credentials","type":"bytes"},
+	{"indexed":false,"name":"amount","type":"bytes"},
+	{"indexed":false,"name":"signature","type":"bytes"},
+	{"indexed":false,"name":"index","type":"bytes"}
+],"name":"DepositEvent","type":"event"}]`
+
+var depositContractABI = mustParseABI(depositEventABI)
+
+// DepositScanner reads the deposits of a deposit contract through an
+// execution JSON-RPC endpoint and indexes the deposited pubkeys by
+// withdrawal address. Every scan continues where the previous one stopped.
+type DepositScanner struct {
+	client     *ethclient.Client
+	contract   common.Address
+	blockRange uint64
+
+	mu sync.Mutex
+	// next is the first block not scanned yet
+	next uint64
+	// pubkeys maps withdrawal addresses to the pubkeys deposited for them
+	pubkeys map[common.Address][]string
+	seen    map[string]bool
+}
+
+// NewDepositScanner connects to rpcURL and scans contract from startBlock,
+// requesting at most blockRange blocks per log query
+func NewDepositScanner(rpcURL, contract string, startBlock, blockRange uint64) (*DepositScanner, error) {
+	if !common.IsHexAddress(contract) {
+		return nil, fmt.Errorf("invalid deposit contract address %q", contract)
+	}
+
+	client, err := ethclient.Dial(rpcURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to execution RPC: %v", err)
+	}
+
+	return &DepositScanner{
+		client:     client,
+		contract:   common.HexToAddress(contract),
+		blockRange: blockRange,
+		next:       startBlock,
+		pubkeys:    make(map[common.Address][]string),
+		seen:       make(map[string]bool),
+	}, nil
+}
+
+// Scan indexes the deposits made since the previous scan
+func (s *DepositScanner) Scan(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	head, err := s.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get block number: %v", err)
+	}
+	if head < confirmations {
+		return nil
+	}
+	head -= confirmations
+
+	for from := s.next; from <= head; from = s.next {
+		to := from + s.blockRange - 1
+		if to > head {
+			to = head
+		}
+
+		logs, err := s.client.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(to),
+			Addresses: []common.Address{s.contract},
+			Topics:    [][]common.Hash{{depositContractABI.Events["DepositEvent"].ID}},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get deposit logs of blocks %d-%d: %v", from, to, err)
+		}
+
+		for _, entry := range logs {
+			if err := s.index(entry.Data); err != nil {
+				return fmt.Errorf("failed to parse deposit in tx %s: %v", entry.TxHash.Hex(), err)
+			}
+		}
+		s.next = to + 1
+	}
+
+	return nil
+}
+
+// Pubkeys returns the pubkeys deposited with address as withdrawal address
+func (s *DepositScanner) Pubkeys(address string) []string {
+	if !common.IsHexAddress(address) {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.pubkeys[common.HexToAddress(address)]...)
+}
+
+// index records the pubkey of a deposit under its withdrawal address. Deposits
+// with BLS withdrawal credentials have no withdrawal address and are ignored.
+func (s *DepositScanner) index(data []byte) error {
+	values, err := depositContractABI.Unpack("DepositEvent", data)
+	if err != nil {
+		return err
+	}
+	if len(values) < 2 {
+		return fmt.Errorf("unexpected number of deposit fields: %d", len(values))
+	}
+
+	pubkey, ok := values[0].([]byte)
+	if !ok {
+		return fmt.Errorf("unexpected pubkey type %T", values[0])
+	}
+	credentials, ok := values[1].([]byte)
+	if !ok {
+		return fmt.Errorf("unexpected withdrawal credentials type %T", values[1])
+	}
+
+	address, ok := withdrawalAddress(credentials)
+	if !ok {
+		return nil
+	}
+
+	// 같은 검증자에 대한 추가 예치는 한 번만 기록
+	key := strings.ToLower(hexutil.Encode(pubkey))
+	if s.seen[key] {
+		return nil
+	}
+	s.seen[key] = true
+	s.pubkeys[address] = append(s.pubkeys[address], key)
+	return nil
+}
+
+// withdrawalAddress returns the execution address of 0x01 or 0x02 withdrawal credentials
+func withdrawalAddress(credentials []byte) (common.Address, bool) {
+	if len(credentials) != 32 || (credentials[0] != 0x01 && credentials[0] != 0x02) {
+		return common.Address{}, false
+	}
+	if !bytes.Equal(credentials[1:12], make([]byte, 11)) {
+		return common.Address{}, false
+	}
+	return common.BytesToAddress(credentials[12:]), true
+}
+
+// mustParseABI parses a constant ABI definition
+func mustParseABI(definition string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
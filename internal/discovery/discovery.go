@@ -0,0 +1,150 @@
+package discovery
+
+import (
+	"context"
+	"dill-monitor/internal/models"
+	"log"
+	"sync"
+)
+
+// Recorder receives every newly discovered validator
+type Recorder interface {
+	RecordValidatorDiscovered(address, label, network string) error
+}
+
+// Discoverer adds the validators deposited with an address as withdrawal
+// address to the addresses that opted in to discovery
+type Discoverer struct {
+	scanners       map[string]*DepositScanner
+	defaultNetwork string
+	recorder       Recorder
+
+	mu sync.Mutex
+	// recorded holds the network and pubkey of every validator counted so far,
+	// so that validators added again after a config reload are counted once
+	recorded map[string]bool
+}
+
+// Validator is a validator discovered for a configured address
+type Validator struct {
+	Address string
+	Label   string
+	Network string
+	Pubkey  string
+}
+
+// New creates a discoverer from the deposit scanners of every network
+// supporting discovery; recorder may be nil
+func New(scanners map[string]*DepositScanner, defaultNetwork string, recorder Recorder) *Discoverer {
+	return &Discoverer{
+		scanners:       scanners,
+		defaultNetwork: defaultNetwork,
+		recorder:       recorder,
+		recorded:       make(map[string]bool),
+	}
+}
+
+// Eligible reports whether the validators of an address are discovered: it
+// either has no validators configured or opted in with "discover": true
+func Eligible(addr models.Address) bool {
+	return addr.Discover || !addr.HasValidators()
+}
+
+// Discover scans the deposits made since the previous call and returns the
+// validators deposited for the eligible addresses that are not configured for
+// any address yet. Addresses on a network whose scan failed are skipped.
+func (d *Discoverer) Discover(ctx context.Context, addresses []models.Address) []Validator {
+	scanned := make(map[string]bool, len(d.scanners))
+	for name, scanner := range d.scanners {
+		if err := scanner.Scan(ctx); err != nil {
+			log.Printf("Error scanning deposits on %s: %v", name, err)
+			continue
+		}
+		scanned[name] = true
+	}
+
+	// 다른 주소에 이미 설정된 검증자도 다시 추가하지 않음
+	known := configuredPubkeys(addresses)
+
+	var discovered []Validator
+	for _, addr := range addresses {
+		network := addr.Network
+		if network == "" {
+			network = d.defaultNetwork
+		}
+		if !scanned[network] || !Eligible(addr) {
+			continue
+		}
+
+		for _, pubkey := range d.scanners[network].Pubkeys(addr.Address) {
+			if known[models.NormalizePubkey(pubkey)] {
+				continue
+			}
+			known[models.NormalizePubkey(pubkey)] = true
+			discovered = append(discovered, Validator{Address: addr.Address, Label: addr.Label, Network: network, Pubkey: pubkey})
+		}
+	}
+	return discovered
+}
+
+// Merge appends the discovered validators to the matching addresses and
+// returns the validators that were added. Validators configured for any
+// address by now and addresses removed in the meantime are skipped, so edits
+// made while discovering are kept. Addresses that got validators are marked
+// with "discover": true so that they keep being discovered.
+func Merge(addresses []models.Address, discovered []Validator) []Validator {
+	known := configuredPubkeys(addresses)
+
+	var added []Validator
+	for _, validator := range discovered {
+		key := models.NormalizePubkey(validator.Pubkey)
+		if known[key] {
+			continue
+		}
+
+		for i := range addresses {
+			if addresses[i].Address != validator.Address {
+				continue
+			}
+			addresses[i].ValidatorAddresses = append(append([]string(nil), addresses[i].ValidatorAddresses...), validator.Pubkey)
+			addresses[i].Discover = true
+			known[key] = true
+			added = append(added, validator)
+			break
+		}
+	}
+	return added
+}
+
+// Record logs the added validators and counts those not counted before
+func (d *Discoverer) Record(added []Validator) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, validator := range added {
+		log.Printf("Discovered validator %s for address %s (%s) on %s", validator.Pubkey, validator.Address, validator.Label, validator.Network)
+
+		key := validator.Network + ":" + models.NormalizePubkey(validator.Pubkey)
+		if d.recorded[key] {
+			continue
+		}
+		d.recorded[key] = true
+		if d.recorder == nil {
+			continue
+		}
+		if err := d.recorder.RecordValidatorDiscovered(validator.Address, validator.Label, validator.Network); err != nil {
+			log.Printf("Error recording discovered validator: %v", err)
+		}
+	}
+}
+
+// configuredPubkeys returns the normalized pubkeys configured for any address
+func configuredPubkeys(addresses []models.Address) map[string]bool {
+	known := make(map[string]bool)
+	for _, addr := range addresses {
+		for _, pubkey := range addr.Pubkeys() {
+			known[models.NormalizePubkey(pubkey)] = true
+		}
+	}
+	return known
+}
@@ -0,0 +1,186 @@
+package discovery
+
+import (
+	"context"
+	"dill-monitor/internal/models"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	withdrawalA = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	withdrawalB = "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359"
+)
+
+// pubkey returns a 0x prefixed test pubkey made of one repeated hex digit
+func pubkey(digit string) string {
+	return "0x" + strings.Repeat(digit, 96)
+}
+
+// testRecorder collects the addresses of counted validators
+type testRecorder struct {
+	discovered []string
+}
+
+func (r *testRecorder) RecordValidatorDiscovered(address, label, network string) error {
+	r.discovered = append(r.discovered, address)
+	return nil
+}
+
+// newTestScanner returns a scanner whose RPC endpoint reports block 0, so
+// that scans succeed without new logs, or fails every request. The deposits
+// map withdrawal addresses to the pubkeys already indexed.
+func newTestScanner(t *testing.T, fail bool, deposits map[string][]string) *DepositScanner {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID json.RawMessage `json:"id"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		if fail {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": request.ID, "result": "0x0"})
+	}))
+	t.Cleanup(server.Close)
+
+	scanner, err := NewDepositScanner(server.URL, "0x00000000219ab540356cBB839Cbe05303d7705Fa", 0, 1000)
+	if err != nil {
+		t.Fatalf("NewDepositScanner: %v", err)
+	}
+	for address, pubkeys := range deposits {
+		for _, key := range pubkeys {
+			data, err := depositContractABI.Events["DepositEvent"].Inputs.Pack(
+				common.FromHex(key), credentials(address), make([]byte, 8), make([]byte, 96), make([]byte, 8))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := scanner.index(data); err != nil {
+				t.Fatalf("index: %v", err)
+			}
+		}
+	}
+	return scanner
+}
+
+// credentials returns 0x01 withdrawal credentials for an address
+func credentials(address string) []byte {
+	return append(append([]byte{0x01}, make([]byte, 11)...), common.HexToAddress(address).Bytes()...)
+}
+
+func TestDepositScannerIndex(t *testing.T) {
+	scanner := newTestScanner(t, false, map[string][]string{withdrawalA: {pubkey("a"), pubkey("b"), pubkey("a")}})
+
+	// 같은 검증자에 대한 추가 예치는 한 번만 기록
+	if got, want := scanner.Pubkeys(strings.ToLower(withdrawalA)), []string{pubkey("a"), pubkey("b")}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Pubkeys = %v, want %v", got, want)
+	}
+	if got := scanner.Pubkeys(withdrawalB); len(got) != 0 {
+		t.Errorf("Pubkeys of another address = %v, want none", got)
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	deposits := map[string][]string{
+		withdrawalA: {pubkey("a"), pubkey("b")},
+		withdrawalB: {pubkey("c")},
+	}
+
+	tests := []struct {
+		name      string
+		fail      bool
+		addresses []models.Address
+		want      []string
+	}{
+		{
+			name:      "address without validators",
+			addresses: []models.Address{{Label: "a", Address: withdrawalA}},
+			want:      []string{pubkey("a"), pubkey("b")},
+		},
+		{
+			name:      "configured for the same address",
+			addresses: []models.Address{{Label: "a", Address: withdrawalA, ValidatorAddress: strings.ToUpper(pubkey("a")[2:]), Discover: true}},
+			want:      []string{pubkey("b")},
+		},
+		{
+			name: "configured for another address",
+			addresses: []models.Address{
+				{Label: "a", Address: withdrawalA},
+				{Label: "b", Address: withdrawalB, ValidatorAddresses: []string{pubkey("b")}},
+			},
+			want: []string{pubkey("a")},
+		},
+		{
+			name:      "not opted in",
+			addresses: []models.Address{{Label: "a", Address: withdrawalA, ValidatorIndices: []string{"1"}}},
+		},
+		{
+			name:      "other network",
+			addresses: []models.Address{{Label: "a", Address: withdrawalA, Network: "andes"}},
+		},
+		{
+			name:      "failed scan",
+			fail:      true,
+			addresses: []models.Address{{Label: "a", Address: withdrawalA}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discoverer := New(map[string]*DepositScanner{"alps": newTestScanner(t, tt.fail, deposits)}, "alps", nil)
+
+			var got []string
+			for _, validator := range discoverer.Discover(context.Background(), tt.addresses) {
+				got = append(got, validator.Pubkey)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("discovered %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	discovered := []Validator{
+		{Address: withdrawalA, Label: "a", Network: "alps", Pubkey: pubkey("a")},
+		{Address: withdrawalA, Label: "a", Network: "alps", Pubkey: pubkey("b")},
+		{Address: withdrawalB, Label: "b", Network: "alps", Pubkey: pubkey("c")},
+	}
+
+	// 탐색하는 동안 b는 삭제되고 a에는 검증자와 인덱스가 직접 추가됨
+	current := []models.Address{{Label: "a", Address: withdrawalA, ValidatorAddresses: []string{pubkey("b")}, ValidatorIndices: []string{"7"}}}
+	added := Merge(current, discovered)
+
+	if len(added) != 1 || added[0].Pubkey != pubkey("a") {
+		t.Errorf("added %+v, want only %s", added, pubkey("a"))
+	}
+	want := []models.Address{{Label: "a", Address: withdrawalA, ValidatorAddresses: []string{pubkey("b"), pubkey("a")}, ValidatorIndices: []string{"7"}, Discover: true}}
+	if !reflect.DeepEqual(current, want) {
+		t.Errorf("addresses = %+v, want %+v", current, want)
+	}
+}
+
+func TestRecord(t *testing.T) {
+	recorder := &testRecorder{}
+	discoverer := New(nil, "alps", recorder)
+	validator := Validator{Address: withdrawalA, Label: "a", Network: "alps", Pubkey: pubkey("a")}
+
+	discoverer.Record([]Validator{validator})
+	// 설정을 다시 읽은 뒤 다시 추가된 검증자는 세지 않음
+	discoverer.Record([]Validator{validator})
+	// 다른 네트워크의 같은 공개키는 다른 검증자
+	validator.Network = "andes"
+	discoverer.Record([]Validator{validator})
+
+	if len(recorder.discovered) != 2 {
+		t.Errorf("counted %d validators, want 2", len(recorder.discovered))
+	}
+}
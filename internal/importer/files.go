@@ -0,0 +1,181 @@
+package importer
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Validator is a validator read from a deposit data or keystore file
+type Validator struct {
+	// Pubkey is the 0x prefixed lower case BLS public key
+	Pubkey string
+	// WithdrawalAddress is empty for keystores and for BLS withdrawal credentials
+	WithdrawalAddress string
+	// Source is the file the validator was read from
+	Source string
+}
+
+// depositData is one entry of a deposit_data-*.json file
+type depositData struct {
+	Pubkey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+}
+
+// keystore holds the fields of an EIP-2335 keystore-*.json file used here
+type keystore struct {
+	Pubkey string `json:"pubkey"`
+}
+
+// ReadFiles reads the validators of the given deposit data and keystore files.
+// Directories are searched recursively for deposit_data-*.json and
+// keystore-*.json files.
+func ReadFiles(paths []string) ([]Validator, error) {
+	var validators []Validator
+	for _, path := range paths {
+		files, err := findFiles(path)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			read, err := readFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %v", file, err)
+			}
+			validators = append(validators, read...)
+		}
+	}
+	return validators, nil
+}
+
+// findFiles returns path itself if it is a file, or the deposit data and
+// keystore files below it if it is a directory
+func findFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	err = filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() && (isDepositData(file) || isKeystore(file)) {
+			files = append(files, file)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// readFile reads the validators of a deposit data or keystore file
+func readFile(file string) ([]Validator, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	// 파일 이름이 규칙과 다르면 내용의 형태로 구분 (deposit data는 배열)
+	if isDepositData(file) || (!isKeystore(file) && strings.HasPrefix(strings.TrimSpace(string(data)), "[")) {
+		return readDepositData(file, data)
+	}
+	return readKeystore(file, data)
+}
+
+// readDepositData parses a deposit_data-*.json file
+func readDepositData(file string, data []byte) ([]Validator, error) {
+	var entries []depositData
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("invalid deposit data: %v", err)
+	}
+
+	validators := make([]Validator, 0, len(entries))
+	for i, entry := range entries {
+		pubkey, err := normalizePubkey(entry.Pubkey)
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %v", i, err)
+		}
+
+		address, err := withdrawalAddress(entry.WithdrawalCredentials)
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %v", i, err)
+		}
+
+		validators = append(validators, Validator{
+			Pubkey:            pubkey,
+			WithdrawalAddress: address,
+			Source:            file,
+		})
+	}
+	return validators, nil
+}
+
+// readKeystore parses a keystore-*.json file
+func readKeystore(file string, data []byte) ([]Validator, error) {
+	var ks keystore
+	if err := json.Unmarshal(data, &ks); err != nil {
+		return nil, fmt.Errorf("invalid keystore: %v", err)
+	}
+
+	pubkey, err := normalizePubkey(ks.Pubkey)
+	if err != nil {
+		return nil, err
+	}
+	return []Validator{{Pubkey: pubkey, Source: file}}, nil
+}
+
+// normalizePubkey validates a hex encoded BLS public key and returns it 0x prefixed in lower case
+func normalizePubkey(pubkey string) (string, error) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(strings.ToLower(strings.TrimSpace(pubkey)), "0x"))
+	if err != nil {
+		return "", fmt.Errorf("invalid pubkey %q: %v", pubkey, err)
+	}
+	if len(raw) != 48 {
+		return "", fmt.Errorf("invalid pubkey %q: expected 48 bytes, got %d", pubkey, len(raw))
+	}
+	return "0x" + hex.EncodeToString(raw), nil
+}
+
+// withdrawalAddress derives the execution address of 0x01 or 0x02 withdrawal
+// credentials. BLS (0x00) credentials have no address and yield "".
+func withdrawalAddress(credentials string) (string, error) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(credentials), "0x"))
+	if err != nil {
+		return "", fmt.Errorf("invalid withdrawal credentials %q: %v", credentials, err)
+	}
+	if len(raw) != 32 {
+		return "", fmt.Errorf("invalid withdrawal credentials %q: expected 32 bytes, got %d", credentials, len(raw))
+	}
+
+	switch raw[0] {
+	case 0x00:
+		return "", nil
+	case 0x01, 0x02:
+		return common.BytesToAddress(raw[12:]).Hex(), nil
+	default:
+		return "", fmt.Errorf("unsupported withdrawal credentials prefix 0x%02x", raw[0])
+	}
+}
+
+// isDepositData reports whether a file name follows the deposit data naming
+func isDepositData(file string) bool {
+	name := filepath.Base(file)
+	return strings.HasPrefix(name, "deposit_data-") && strings.HasSuffix(name, ".json")
+}
+
+// isKeystore reports whether a file name follows the keystore naming
+func isKeystore(file string) bool {
+	name := filepath.Base(file)
+	return strings.HasPrefix(name, "keystore-") && strings.HasSuffix(name, ".json")
+}
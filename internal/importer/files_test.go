@@ -0,0 +1,92 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const (
+	withdrawalA = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	withdrawalB = "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359"
+)
+
+// pubkey returns a 0x prefixed test pubkey made of one repeated hex digit
+func pubkey(digit string) string {
+	return "0x" + strings.Repeat(digit, 96)
+}
+
+// credentials returns 0x01 withdrawal credentials for an address
+func credentials(address string) string {
+	return "01" + strings.Repeat("0", 22) + strings.ToLower(strings.TrimPrefix(address, "0x"))
+}
+
+// writeFile writes a file below dir, creating its parent directories
+func writeFile(t *testing.T, dir, name, contents string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "deposit_data-1.json", `[
+		{"pubkey": "`+strings.Repeat("a", 96)+`", "withdrawal_credentials": "`+credentials(withdrawalA)+`"},
+		{"pubkey": "`+strings.Repeat("B", 96)+`", "withdrawal_credentials": "00`+strings.Repeat("1", 62)+`"}
+	]`)
+	writeFile(t, dir, "validator_keys/keystore-m_12381_3600_0_0_0-1.json", `{"pubkey": "`+strings.Repeat("c", 96)+`", "crypto": {}}`)
+	writeFile(t, dir, "validator_keys/README.txt", "ignored")
+
+	validators, err := ReadFiles([]string{dir})
+	if err != nil {
+		t.Fatalf("ReadFiles: %v", err)
+	}
+
+	type read struct{ pubkey, address string }
+	var got []read
+	for _, validator := range validators {
+		got = append(got, read{validator.Pubkey, validator.WithdrawalAddress})
+	}
+	want := []read{
+		{pubkey("a"), withdrawalA},
+		// BLS 출금 자격 증명에는 주소가 없음
+		{pubkey("b"), ""},
+		{pubkey("c"), ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("validators = %v, want %v", got, want)
+	}
+}
+
+func TestReadFilesErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		contents string
+		err      string
+	}{
+		{"invalid JSON", "deposit_data-1.json", `[{`, "invalid deposit data"},
+		{"short pubkey", "deposit_data-1.json", `[{"pubkey": "abcd", "withdrawal_credentials": "` + credentials(withdrawalA) + `"}]`, "expected 48 bytes, got 2"},
+		{"unsupported credentials", "deposit_data-1.json", `[{"pubkey": "` + strings.Repeat("a", 96) + `", "withdrawal_credentials": "03` + strings.Repeat("0", 62) + `"}]`, "unsupported withdrawal credentials prefix 0x03"},
+		{"keystore without pubkey", "keystore-1.json", `{"crypto": {}}`, "invalid pubkey"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFile(t, t.TempDir(), tt.file, tt.contents)
+			_, err := ReadFiles([]string{path})
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("ReadFiles error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package importer
+
+import (
+	"dill-monitor/internal/config"
+	"dill-monitor/internal/models"
+	"fmt"
+	"strings"
+)
+
+// Options controls how imported validators are merged into the config
+type Options struct {
+	// Address assigns every validator to this address, overriding the
+	// withdrawal address; required for keystores without deposit data
+	Address string
+	// Label is used for addresses added by the import. When the import adds
+	// several addresses, each gets the label suffixed with its short address.
+	Label string
+	// Network is set on addresses added by the import
+	Network string
+}
+
+// Result summarises an import
+type Result struct {
+	// Added lists the addresses added to the config
+	Added []string
+	// Imported is the number of validators added to the config
+	Imported int
+	// Duplicates lists the validators that were already configured
+	Duplicates []string
+	// Skipped lists the validators without an address to assign them to
+	Skipped []string
+}
+
+// Merge adds the validators to the addresses of cfg, adding an address for
+// every withdrawal address not configured yet. Validators that are already
+// configured are reported as duplicates instead of failing the import.
+func Merge(cfg *config.Config, validators []Validator, opts Options) *Result {
+	result := &Result{}
+
+	// 같은 검증자가 deposit data와 keystore에 모두 있으면 deposit data의 주소를 사용
+	addresses := make(map[string]string)
+	for _, validator := range validators {
+		if validator.WithdrawalAddress != "" {
+			addresses[validator.Pubkey] = validator.WithdrawalAddress
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, validator := range validators {
+		if seen[validator.Pubkey] {
+			continue
+		}
+		seen[validator.Pubkey] = true
+
+		address := opts.Address
+		if address == "" {
+			address = addresses[validator.Pubkey]
+		}
+		if address == "" {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("%s (%s): no withdrawal address", validator.Pubkey, validator.Source))
+			continue
+		}
+
+		if configured := findValidator(cfg, validator.Pubkey); configured != "" {
+			result.Duplicates = append(result.Duplicates, fmt.Sprintf("%s: already configured for %s", validator.Pubkey, configured))
+			continue
+		}
+
+		if existing := findAddress(cfg, address); existing != nil {
+			appendValidator(existing, validator.Pubkey)
+			result.Imported++
+			continue
+		}
+
+		label := opts.Label
+		if label == "" {
+			label = "imported-" + shortAddress(address)
+		}
+		if err := cfg.AddAddress(models.Address{
+			Label:            label,
+			Address:          address,
+			ValidatorAddress: validator.Pubkey,
+			Network:          opts.Network,
+		}); err != nil {
+			result.Duplicates = append(result.Duplicates, fmt.Sprintf("%s: %v", address, err))
+			continue
+		}
+		result.Added = append(result.Added, address)
+		result.Imported++
+	}
+
+	// 여러 주소가 추가되면 같은 레이블을 쓸 수 없으므로 주소로 구분
+	if opts.Label != "" && len(result.Added) > 1 {
+		for _, added := range result.Added {
+			findAddress(cfg, added).Label = opts.Label + "-" + shortAddress(added)
+		}
+	}
+
+	return result
+}
+
+// findAddress returns the configured address, ignoring case
+func findAddress(cfg *config.Config, address string) *models.Address {
+	for i := range cfg.Addresses {
+		if strings.EqualFold(cfg.Addresses[i].Address, address) {
+			return &cfg.Addresses[i]
+		}
+	}
+	return nil
+}
+
+// findValidator returns the address a pubkey is configured for, or ""
+func findValidator(cfg *config.Config, pubkey string) string {
+	want := models.NormalizePubkey(pubkey)
+	for _, addr := range cfg.Addresses {
+		for _, configured := range addr.Pubkeys() {
+			if models.NormalizePubkey(configured) == want {
+				return addr.Address
+			}
+		}
+	}
+	return ""
+}
+
+// appendValidator adds a pubkey to an address, using validator_address if it is free
+func appendValidator(addr *models.Address, pubkey string) {
+	if addr.ValidatorAddress == "" {
+		addr.ValidatorAddress = pubkey
+		return
+	}
+	addr.ValidatorAddresses = append(addr.ValidatorAddresses, pubkey)
+}
+
+// shortAddress returns the first bytes of an address for generated labels
+func shortAddress(address string) string {
+	address = strings.TrimPrefix(address, "0x")
+	if len(address) > 8 {
+		address = address[:8]
+	}
+	return strings.ToLower(address)
+}
@@ -0,0 +1,102 @@
+package importer
+
+import (
+	"dill-monitor/internal/config"
+	"dill-monitor/internal/models"
+	"reflect"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name       string
+		addresses  []models.Address
+		validators []Validator
+		opts       Options
+		// want are the addresses of the config after the merge
+		want       []models.Address
+		imported   int
+		duplicates int
+		skipped    int
+	}{
+		{
+			name:       "new withdrawal address",
+			validators: []Validator{{Pubkey: pubkey("a"), WithdrawalAddress: withdrawalA}},
+			want:       []models.Address{{Label: "imported-5aaeb605", Address: withdrawalA, ValidatorAddress: pubkey("a")}},
+			imported:   1,
+		},
+		{
+			name:      "existing address",
+			addresses: []models.Address{{Label: "a", Address: withdrawalA, ValidatorAddress: pubkey("a")}},
+			validators: []Validator{
+				{Pubkey: pubkey("b"), WithdrawalAddress: withdrawalA},
+				{Pubkey: pubkey("c"), WithdrawalAddress: withdrawalA},
+			},
+			want:     []models.Address{{Label: "a", Address: withdrawalA, ValidatorAddress: pubkey("a"), ValidatorAddresses: []string{pubkey("b"), pubkey("c")}}},
+			imported: 2,
+		},
+		{
+			name:      "already configured for another address",
+			addresses: []models.Address{{Label: "a", Address: withdrawalA, ValidatorAddress: pubkey("a")}},
+			validators: []Validator{
+				{Pubkey: pubkey("a"), WithdrawalAddress: withdrawalB},
+			},
+			want:       []models.Address{{Label: "a", Address: withdrawalA, ValidatorAddress: pubkey("a")}},
+			duplicates: 1,
+		},
+		{
+			name: "keystore matched with deposit data",
+			validators: []Validator{
+				{Pubkey: pubkey("a"), Source: "keystore-1.json"},
+				{Pubkey: pubkey("a"), WithdrawalAddress: withdrawalA, Source: "deposit_data-1.json"},
+				{Pubkey: pubkey("b"), Source: "keystore-2.json"},
+			},
+			opts:     Options{Network: "andes"},
+			want:     []models.Address{{Label: "imported-5aaeb605", Address: withdrawalA, ValidatorAddress: pubkey("a"), Network: "andes"}},
+			imported: 1,
+			skipped:  1,
+		},
+		{
+			name: "address option",
+			validators: []Validator{
+				{Pubkey: pubkey("a"), WithdrawalAddress: withdrawalA},
+				{Pubkey: pubkey("b")},
+			},
+			opts:     Options{Address: withdrawalB, Label: "node"},
+			want:     []models.Address{{Label: "node", Address: withdrawalB, ValidatorAddress: pubkey("a"), ValidatorAddresses: []string{pubkey("b")}}},
+			imported: 2,
+		},
+		{
+			name: "label of several new addresses",
+			validators: []Validator{
+				{Pubkey: pubkey("a"), WithdrawalAddress: withdrawalA},
+				{Pubkey: pubkey("b"), WithdrawalAddress: withdrawalB},
+			},
+			opts: Options{Label: "node"},
+			want: []models.Address{
+				{Label: "node-5aaeb605", Address: withdrawalA, ValidatorAddress: pubkey("a")},
+				{Label: "node-fb691609", Address: withdrawalB, ValidatorAddress: pubkey("b")},
+			},
+			imported: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Addresses: tt.addresses}
+			result := Merge(cfg, tt.validators, tt.opts)
+
+			if !reflect.DeepEqual(cfg.Addresses, tt.want) {
+				t.Errorf("addresses = %+v, want %+v", cfg.Addresses, tt.want)
+			}
+			if result.Imported != tt.imported || len(result.Duplicates) != tt.duplicates || len(result.Skipped) != tt.skipped {
+				t.Errorf("imported, duplicates, skipped = %d, %v, %v, want %d, %d, %d",
+					result.Imported, result.Duplicates, result.Skipped, tt.imported, tt.duplicates, tt.skipped)
+			}
+			// 병합된 설정은 저장 전 검증을 통과해야 함
+			if err := cfg.Validate(); err != nil {
+				t.Errorf("Validate: %v", err)
+			}
+		})
+	}
+}
@@ -1,9 +1,31 @@
 package models
 
+import "strings"
+
+// Sources a balance is assembled from
+const (
+	// SourceAddress covers processing of the whole address
+	SourceAddress         = "address"
+	SourceBalance         = "balance"
+	SourceStaker          = "staker"
+	SourceValidator       = "validator"
+	SourceValidatorDetail = "validator_detail"
+)
+
+// SourceStatus describes the provenance of the fields provided by one source
+type SourceStatus struct {
+	// Stale is true when the last fetch failed and the fields hold older values
+	Stale bool `json:"stale"`
+	// UpdatedAt is the RFC3339 time the fields were last fetched successfully
+	UpdatedAt string `json:"updated_at,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
 // Balance represents the balance information for an account
 type Balance struct {
 	Label                 string `json:"label"`
 	Address               string `json:"address"`
+	Network               string `json:"network"`
 	ValidatorAddress      string `json:"validator_address"`
 	ValidatorIndex        string `json:"validator_index"`
 	Status                string `json:"status"`
@@ -17,6 +39,47 @@ type Balance struct {
 	LastRewardTime        string `json:"last_reward_time"`
 	LatestIncome          string `json:"latest_income"`
 	DailyReward           string `json:"daily_reward"`
+	// Sources maps each fetched source to the provenance of its fields
+	Sources map[string]SourceStatus `json:"sources,omitempty"`
+	// Validators holds every validator backing the address. StakingBalance,
+	// LatestIncome and DailyReward are summed over them and LastEpoch is the
+	// latest; ValidatorIndex and Status are only set for a single validator.
+	Validators []ValidatorBalance `json:"validators,omitempty"`
+}
+
+// HasStaleSource reports whether any source of the balance holds older values
+func (b *Balance) HasStaleSource() bool {
+	for _, status := range b.Sources {
+		if status.Stale {
+			return true
+		}
+	}
+	return false
+}
+
+// KnownValidators returns the validators of the balance whose index is known
+func (b *Balance) KnownValidators() []ValidatorBalance {
+	var validators []ValidatorBalance
+	for _, validator := range b.Validators {
+		if strings.TrimSpace(validator.Index) != "" {
+			validators = append(validators, validator)
+		}
+	}
+	return validators
+}
+
+// ValidatorBalance represents the state of one validator backing an address
+type ValidatorBalance struct {
+	Pubkey         string `json:"pubkey,omitempty"`
+	Index          string `json:"index"`
+	Status         string `json:"status"`
+	StakingBalance string `json:"staking_balance"`
+	LastEpoch      string `json:"last_epoch"`
+	LastRewardTime string `json:"last_reward_time"`
+	LatestIncome   string `json:"latest_income"`
+	DailyReward    string `json:"daily_reward"`
+	// Stale is true when the validator could not be fetched and holds older values
+	Stale bool `json:"stale,omitempty"`
 }
 
 // ValidatorReward represents the reward information for a validator
@@ -28,6 +91,7 @@ type ValidatorReward struct {
 	Balance      string  `json:"balance"`
 	Status       string  `json:"status"`
 	UserLabel    string  `json:"user_label"`
+	Network      string  `json:"network"`
 }
 
 // Config represents the application configuration
@@ -40,6 +104,42 @@ type Address struct {
 	Label            string `json:"label"`
 	Address          string `json:"address"`
 	ValidatorAddress string `json:"validator_address"`
+	// ValidatorAddresses lists further validator pubkeys backing the address
+	ValidatorAddresses []string `json:"validator_addresses,omitempty"`
+	// ValidatorIndices lists validators backing the address by index
+	ValidatorIndices []string `json:"validator_indices,omitempty"`
+	// Discover adds the validators discovered from deposits to ValidatorAddresses
+	Discover bool   `json:"discover,omitempty"`
+	Network  string `json:"network,omitempty"`
+	// Interval overrides the default polling interval, e.g. "15m", or "epoch"
+	// to poll shortly after every epoch boundary
+	Interval string `json:"interval,omitempty"`
+}
+
+// Pubkeys returns the validator pubkeys of the address without duplicates
+func (a Address) Pubkeys() []string {
+	var pubkeys []string
+	seen := make(map[string]bool)
+	for _, pubkey := range append([]string{a.ValidatorAddress}, a.ValidatorAddresses...) {
+		key := NormalizePubkey(pubkey)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		pubkeys = append(pubkeys, pubkey)
+	}
+	return pubkeys
+}
+
+// NormalizePubkey returns the lower case hex form of a pubkey without 0x prefix
+func NormalizePubkey(pubkey string) string {
+	pubkey = strings.ToLower(strings.TrimSpace(pubkey))
+	return strings.TrimPrefix(pubkey, "0x")
+}
+
+// HasValidators reports whether the address is backed by any validator
+func (a Address) HasValidators() bool {
+	return len(a.Pubkeys()) > 0 || len(a.ValidatorIndices) > 0
 }
 
 // StakerResponse represents the response from the staker API
@@ -52,6 +152,7 @@ type StakerResponse struct {
 
 // ValidatorInfo represents validator information from the API
 type ValidatorInfo struct {
+	Pubkey  string `json:"pubkey"`
 	Index   string `json:"index"`
 	Status  string `json:"status"`
 	Balance string `json:"balance"`
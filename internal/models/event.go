@@ -0,0 +1,99 @@
+package models
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Kinds of validator lifecycle events
+const (
+	EventObserved         = "observed"
+	EventDeposited        = "deposited"
+	EventActivationQueued = "activation_queued"
+	EventActivated        = "activated"
+	EventSlashed          = "slashed"
+	EventExiting          = "exiting"
+	EventExited           = "exited"
+	EventWithdrawable     = "withdrawable"
+	EventWithdrawn        = "withdrawn"
+	EventStatusChanged    = "status_changed"
+)
+
+// ValidatorEvent is a status transition of a validator in its lifecycle, e.g.
+// pending_queued -> active_ongoing. The first status a validator is seen
+// with is recorded as an event without From.
+type ValidatorEvent struct {
+	ValidatorIdx string `json:"validator_idx"`
+	Label        string `json:"label"`
+	Network      string `json:"network"`
+	// Kind summarizes the transition, e.g. EventActivated
+	Kind string `json:"kind"`
+	From string `json:"from,omitempty"`
+	To   string `json:"to"`
+	// Epoch is the epoch the transition was detected in
+	Epoch uint64    `json:"epoch"`
+	Time  time.Time `json:"time"`
+}
+
+// EventQuery selects validator events. Empty fields match every event.
+type EventQuery struct {
+	ValidatorIdx string
+	Network      string
+	From         time.Time
+	To           time.Time
+}
+
+// Matches reports whether the event is selected by the query
+func (q EventQuery) Matches(event *ValidatorEvent) bool {
+	if q.ValidatorIdx != "" && event.ValidatorIdx != q.ValidatorIdx {
+		return false
+	}
+	if q.Network != "" && event.Network != q.Network {
+		return false
+	}
+	if !q.From.IsZero() && event.Time.Before(q.From) {
+		return false
+	}
+	if !q.To.IsZero() && event.Time.After(q.To) {
+		return false
+	}
+	return true
+}
+
+// SortEvents sorts events by time
+func SortEvents(events []*ValidatorEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+}
+
+// EventKind returns the kind of a transition from one validator status to another
+func EventKind(from, to string) string {
+	if from == "" {
+		return EventObserved
+	}
+
+	// 슬래싱은 active_slashed, exited_slashed 어느 쪽으로 바뀌어도 기록
+	if strings.Contains(to, "slashed") && !strings.Contains(from, "slashed") {
+		return EventSlashed
+	}
+	switch {
+	case to == "pending_initialized":
+		return EventDeposited
+	case to == "pending_queued":
+		return EventActivationQueued
+	case to == "active_ongoing":
+		return EventActivated
+	case to == "active_exiting":
+		return EventExiting
+	case strings.HasPrefix(to, "exited"):
+		return EventExited
+	case to == "withdrawal_possible":
+		return EventWithdrawable
+	case to == "withdrawal_done":
+		return EventWithdrawn
+	default:
+		return EventStatusChanged
+	}
+}
@@ -0,0 +1,183 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Subjects of history snapshots
+const (
+	SubjectAddress   = "address"
+	SubjectValidator = "validator"
+)
+
+// Resolutions history can be queried and kept at
+const (
+	ResolutionRaw    = "raw"
+	ResolutionHourly = "hourly"
+	ResolutionDaily  = "daily"
+)
+
+// Snapshot is the state of an address or validator at one point in time.
+// Amounts are in DILL.
+type Snapshot struct {
+	Time time.Time `json:"time"`
+	// Subject is SubjectAddress or SubjectValidator
+	Subject string `json:"subject"`
+	// Key is the address or the validator index
+	Key            string  `json:"key"`
+	Label          string  `json:"label"`
+	Network        string  `json:"network"`
+	Status         string  `json:"status,omitempty"`
+	Balance        float64 `json:"balance,omitempty"`
+	StakingBalance float64 `json:"staking_balance"`
+	StakedAmount   float64 `json:"staked_amount,omitempty"`
+	Reward         float64 `json:"reward,omitempty"`
+	DailyReward    float64 `json:"daily_reward"`
+	LatestIncome   float64 `json:"latest_income"`
+	LastEpoch      uint64  `json:"last_epoch,omitempty"`
+}
+
+// HistoryQuery selects the snapshots of one address or validator on a network
+type HistoryQuery struct {
+	Subject    string
+	Key        string
+	Network    string
+	From       time.Time
+	To         time.Time
+	Resolution string
+}
+
+// RetentionPolicy is how long snapshots are kept at each resolution before
+// they are downsampled to the next one, or deleted after Daily. Zero keeps
+// them forever.
+type RetentionPolicy struct {
+	Raw    time.Duration
+	Hourly time.Duration
+	Daily  time.Duration
+}
+
+// ResolutionPeriod returns the length of the buckets of a resolution, or zero for raw
+func ResolutionPeriod(resolution string) (time.Duration, error) {
+	switch resolution {
+	case "", ResolutionRaw:
+		return 0, nil
+	case ResolutionHourly:
+		return time.Hour, nil
+	case ResolutionDaily:
+		return 24 * time.Hour, nil
+	default:
+		return 0, fmt.Errorf("invalid resolution %q: expected %q, %q or %q", resolution, ResolutionRaw, ResolutionHourly, ResolutionDaily)
+	}
+}
+
+// Downsample keeps the latest snapshot of every period, since all snapshot
+// values are gauges. snapshots must be sorted by time.
+func Downsample(snapshots []*Snapshot, period time.Duration) []*Snapshot {
+	if period <= 0 {
+		return snapshots
+	}
+
+	var downsampled []*Snapshot
+	for _, snapshot := range snapshots {
+		// UTC 기준으로 시간/일 단위 버킷을 나눔
+		bucket := snapshot.Time.Truncate(period)
+		if n := len(downsampled); n > 0 && downsampled[n-1].Time.Truncate(period).Equal(bucket) {
+			downsampled[n-1] = snapshot
+			continue
+		}
+		downsampled = append(downsampled, snapshot)
+	}
+	return downsampled
+}
+
+// SortSnapshots sorts snapshots by time
+func SortSnapshots(snapshots []*Snapshot) {
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].Time.Before(snapshots[j].Time)
+	})
+}
+
+// BalanceSnapshots returns the snapshots of the balances processed in one
+// cycle: one per address and one per validator with a known index. A
+// validator backing several addresses is included once. Values kept from an
+// earlier cycle are not new data points, so addresses with a stale source
+// and stale validators are left out.
+func BalanceSnapshots(balances []*Balance, at time.Time) []*Snapshot {
+	var snapshots []*Snapshot
+	seen := make(map[string]bool)
+	for _, balance := range balances {
+		if balance.HasStaleSource() {
+			// 주소 스냅샷만 건너뛰고 새로 조회된 검증자는 기록
+			snapshots = append(snapshots, validatorSnapshots(balance, at, seen)...)
+			continue
+		}
+
+		snapshots = append(snapshots, &Snapshot{
+			Time:           at,
+			Subject:        SubjectAddress,
+			Key:            balance.Address,
+			Label:          balance.Label,
+			Network:        balance.Network,
+			Status:         balance.Status,
+			Balance:        parseAmount(strings.TrimSuffix(balance.Balance, " DILL")),
+			StakingBalance: parseAmount(balance.StakingBalance),
+			StakedAmount:   parseAmount(balance.StakedAmount),
+			Reward:         parseAmount(balance.Reward),
+			DailyReward:    parseAmount(balance.DailyReward),
+			LatestIncome:   parseAmount(balance.LatestIncome),
+			LastEpoch:      parseEpoch(balance.LastEpoch),
+		})
+		snapshots = append(snapshots, validatorSnapshots(balance, at, seen)...)
+	}
+	return snapshots
+}
+
+// validatorSnapshots returns the snapshots of the fresh validators of a
+// balance with a known index that are not in seen yet, and adds them to seen
+func validatorSnapshots(balance *Balance, at time.Time, seen map[string]bool) []*Snapshot {
+	var snapshots []*Snapshot
+	for _, validator := range balance.KnownValidators() {
+		// 검증자 인덱스는 네트워크 안에서만 고유
+		key := balance.Network + "/" + validator.Index
+		if validator.Stale || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		snapshots = append(snapshots, &Snapshot{
+			Time:           at,
+			Subject:        SubjectValidator,
+			Key:            validator.Index,
+			Label:          balance.Label,
+			Network:        balance.Network,
+			Status:         validator.Status,
+			StakingBalance: parseAmount(validator.StakingBalance),
+			DailyReward:    parseAmount(validator.DailyReward),
+			LatestIncome:   parseAmount(validator.LatestIncome),
+			LastEpoch:      parseEpoch(validator.LastEpoch),
+		})
+	}
+	return snapshots
+}
+
+// parseAmount parses a decimal amount, treating missing or invalid values as zero
+func parseAmount(value string) float64 {
+	amount, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0
+	}
+	return amount
+}
+
+// parseEpoch parses an epoch number, treating missing or invalid values as zero
+func parseEpoch(value string) uint64 {
+	epoch, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return epoch
+}
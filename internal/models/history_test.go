@@ -0,0 +1,135 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// snapshotsAt returns address snapshots with the given staking balances, one per offset from start
+func snapshotsAt(start time.Time, offsets []time.Duration) []*Snapshot {
+	snapshots := make([]*Snapshot, 0, len(offsets))
+	for i, offset := range offsets {
+		snapshots = append(snapshots, &Snapshot{Time: start.Add(offset), Subject: SubjectAddress, StakingBalance: float64(i)})
+	}
+	return snapshots
+}
+
+func TestDownsample(t *testing.T) {
+	start := time.Date(2025, 5, 19, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		offsets []time.Duration
+		period  time.Duration
+		// want are the staking balances kept, which are the indexes of the input snapshots
+		want []float64
+	}{
+		{"raw keeps everything", []time.Duration{0, time.Minute, 2 * time.Minute}, 0, []float64{0, 1, 2}},
+		{"latest of every hour", []time.Duration{0, 30 * time.Minute, 59 * time.Minute, time.Hour, 90 * time.Minute}, time.Hour, []float64{2, 4}},
+		{"gaps", []time.Duration{0, 5 * time.Hour}, time.Hour, []float64{0, 1}},
+		{"latest of every day", []time.Duration{time.Hour, 23 * time.Hour, 25 * time.Hour}, 24 * time.Hour, []float64{1, 2}},
+		{"empty", nil, time.Hour, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []float64
+			for _, snapshot := range Downsample(snapshotsAt(start, tt.offsets), tt.period) {
+				got = append(got, snapshot.StakingBalance)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("kept %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolutionPeriod(t *testing.T) {
+	tests := []struct {
+		resolution string
+		want       time.Duration
+		valid      bool
+	}{
+		{"", 0, true},
+		{ResolutionRaw, 0, true},
+		{ResolutionHourly, time.Hour, true},
+		{ResolutionDaily, 24 * time.Hour, true},
+		{"weekly", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, err := ResolutionPeriod(tt.resolution)
+		if (err == nil) != tt.valid || got != tt.want {
+			t.Errorf("ResolutionPeriod(%q) = %v, %v, want %v, valid %v", tt.resolution, got, err, tt.want, tt.valid)
+		}
+	}
+}
+
+func TestBalanceSnapshotsSkipStale(t *testing.T) {
+	at := time.Date(2025, 5, 19, 12, 0, 0, 0, time.UTC)
+
+	balances := []*Balance{
+		// 잔액 조회가 실패한 주소는 건너뛰고 새로 조회된 검증자만 기록
+		{
+			Address: "0x1", Network: "alps",
+			Sources:    map[string]SourceStatus{SourceBalance: {Stale: true}, SourceValidator: {}},
+			Validators: []ValidatorBalance{{Index: "1"}, {Index: "2", Stale: true}},
+		},
+		// 다른 주소에서 새로 조회된 같은 검증자는 기록하고, 검증자 소스가 오래된 주소는 건너뜀
+		{
+			Address: "0x2", Network: "alps",
+			Sources:    map[string]SourceStatus{SourceBalance: {}, SourceValidator: {Stale: true}},
+			Validators: []ValidatorBalance{{Index: "2"}, {Index: "3", Stale: true}},
+		},
+	}
+
+	var got []string
+	for _, snapshot := range BalanceSnapshots(balances, at) {
+		got = append(got, snapshot.Subject+":"+snapshot.Key)
+	}
+	want := []string{"validator:1", "validator:2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("snapshots = %v, want %v", got, want)
+	}
+}
+
+func TestBalanceSnapshots(t *testing.T) {
+	at := time.Date(2025, 5, 19, 12, 0, 0, 0, time.UTC)
+	shared := ValidatorBalance{Index: "17021", Status: "active_ongoing", StakingBalance: "3600.500", LastEpoch: "57006"}
+
+	balances := []*Balance{
+		{
+			Label: "a", Address: "0x1", Network: "alps", Balance: "1.5000000000 DILL", StakingBalance: "3600.500", LastEpoch: "57006",
+			Validators: []ValidatorBalance{shared, {Pubkey: "0xaa", StakingBalance: "0"}},
+		},
+		// 같은 네트워크의 같은 검증자는 한 번만 기록
+		{Label: "b", Address: "0x2", Network: "alps", Validators: []ValidatorBalance{shared}},
+		// 다른 네트워크의 같은 인덱스는 다른 검증자
+		{Label: "c", Address: "0x3", Network: "andes", Validators: []ValidatorBalance{shared}},
+	}
+
+	type key struct{ subject, key, network string }
+	var got []key
+	for _, snapshot := range BalanceSnapshots(balances, at) {
+		if !snapshot.Time.Equal(at) {
+			t.Errorf("snapshot of %s at %v, want %v", snapshot.Key, snapshot.Time, at)
+		}
+		got = append(got, key{snapshot.Subject, snapshot.Key, snapshot.Network})
+	}
+	want := []key{
+		{SubjectAddress, "0x1", "alps"},
+		{SubjectValidator, "17021", "alps"},
+		{SubjectAddress, "0x2", "alps"},
+		{SubjectAddress, "0x3", "andes"},
+		{SubjectValidator, "17021", "andes"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("snapshots = %v, want %v", got, want)
+	}
+
+	first := BalanceSnapshots(balances[:1], at)[0]
+	if first.Balance != 1.5 || first.StakingBalance != 3600.5 || first.LastEpoch != 57006 {
+		t.Errorf("address snapshot = %+v, want the parsed amounts", first)
+	}
+}
@@ -0,0 +1,26 @@
+package models
+
+// NetworkProfile describes the endpoints and units of a Dill network
+type NetworkProfile struct {
+	ExplorerURL string `json:"explorerUrl,omitempty"`
+	StakerURL   string `json:"stakerUrl,omitempty"`
+	// ExecutionRPCURL often embeds an API key, so it is masked when the config is printed
+	ExecutionRPCURL string `json:"executionRpcUrl,omitempty" redact:"true"`
+	GweiDecimals    int    `json:"gweiDecimals,omitempty"`
+	WeiDecimals     int    `json:"weiDecimals,omitempty"`
+	// GenesisTime is the unix timestamp of the beacon chain genesis, required for epoch-aligned polling
+	GenesisTime    int64  `json:"genesisTime,omitempty"`
+	SecondsPerSlot uint64 `json:"secondsPerSlot,omitempty"`
+	SlotsPerEpoch  uint64 `json:"slotsPerEpoch,omitempty"`
+	// DepositContract is the address of the deposit contract on the execution
+	// layer, required together with ExecutionRPCURL for validator discovery
+	DepositContract string `json:"depositContract,omitempty"`
+	// DepositContractBlock is the block the deposit contract was deployed in
+	DepositContractBlock uint64 `json:"depositContractBlock,omitempty"`
+}
+
+// NetworkConfig selects the default network and declares custom or overriding profiles
+type NetworkConfig struct {
+	Default  string                    `json:"default"`
+	Profiles map[string]NetworkProfile `json:"profiles"`
+}
@@ -2,8 +2,87 @@ package models
 
 // ServerConfig represents server specific configuration
 type ServerConfig struct {
-	MetricsPort int    `json:"metricsPort"`
-	LogLevel    string `json:"logLevel"`
-	Host        string `json:"host"`
+	MetricsPort int             `json:"metricsPort"`
+	LogLevel    string          `json:"logLevel"`
+	Host        string          `json:"host"`
+	Network     NetworkConfig   `json:"network"`
+	Upstream    UpstreamConfig  `json:"upstream"`
+	Polling     PollingConfig   `json:"polling"`
+	Discovery   DiscoveryConfig `json:"discovery"`
+	Storage     StorageConfig   `json:"storage"`
 	// 기타 서버 관련 설정 추가 가능
 }
+
+// ServerSection is the server section of the unified config document. It
+// holds the fields of ServerConfig except the networks, which have their own
+// section.
+type ServerSection struct {
+	MetricsPort int             `json:"metricsPort,omitempty"`
+	LogLevel    string          `json:"logLevel,omitempty"`
+	Host        string          `json:"host,omitempty"`
+	Upstream    UpstreamConfig  `json:"upstream"`
+	Polling     PollingConfig   `json:"polling"`
+	Discovery   DiscoveryConfig `json:"discovery"`
+	Storage     StorageConfig   `json:"storage"`
+}
+
+// AlertsConfig maps alert names to thresholds, e.g. "min_balance": 32. The
+// thresholds are exported as dill_alert_threshold{alert="..."} so alerting
+// rules can be tuned from the config instead of the rule files.
+type AlertsConfig map[string]float64
+
+// PollingConfig controls how often addresses are processed.
+// Durations use Go duration syntax ("1m", "30s").
+type PollingConfig struct {
+	// Interval is the default polling interval of every address
+	Interval string `json:"interval,omitempty"`
+	// Jitter is the maximum random delay added to every scheduled run
+	Jitter string `json:"jitter,omitempty"`
+	// Mode is "interval" (default) or "epoch" to poll shortly after every epoch boundary
+	Mode string `json:"mode,omitempty"`
+	// EpochOffset is the delay after an epoch boundary in epoch mode
+	EpochOffset string `json:"epochOffset,omitempty"`
+	// Workers is the number of addresses processed concurrently
+	Workers int `json:"workers,omitempty"`
+	// CycleTimeout bounds a processing cycle; empty derives it from the polling interval
+	CycleTimeout string `json:"cycleTimeout,omitempty"`
+	// Overlap is "queue" (default) or "skip" for addresses due while a cycle is running
+	Overlap string `json:"overlap,omitempty"`
+}
+
+// StorageConfig selects where balances and validator rewards are kept
+type StorageConfig struct {
+	// Backend is "memory" (default) or "bolt" to persist them in an embedded database
+	Backend string `json:"backend,omitempty"`
+	// Path is the database file of the bolt backend; defaults to
+	// dill-monitor.db next to the config file
+	Path string `json:"path,omitempty"`
+	// History controls how long snapshots are kept by the bolt backend
+	History HistoryConfig `json:"history"`
+}
+
+// HistoryConfig sets how long snapshots are kept at each resolution before
+// they are downsampled to the next coarser one. Durations use Go duration
+// syntax; "0" keeps them forever.
+type HistoryConfig struct {
+	// RawRetention is how long every snapshot is kept (default "168h")
+	RawRetention string `json:"rawRetention,omitempty"`
+	// HourlyRetention is how long hourly snapshots are kept (default "2160h")
+	HourlyRetention string `json:"hourlyRetention,omitempty"`
+	// DailyRetention is how long daily snapshots are kept (default forever)
+	DailyRetention string `json:"dailyRetention,omitempty"`
+}
+
+// DiscoveryConfig controls the discovery of validators from the deposits made
+// with an address as withdrawal address
+type DiscoveryConfig struct {
+	// Enabled turns on discovery for addresses without configured validators
+	// and for addresses with "discover": true
+	Enabled bool `json:"enabled,omitempty"`
+	// Interval is the time between two scans of the deposit contract
+	Interval string `json:"interval,omitempty"`
+	// Persist writes discovered validators back to the address config
+	Persist bool `json:"persist,omitempty"`
+	// BlockRange is the number of blocks requested per log query
+	BlockRange uint64 `json:"blockRange,omitempty"`
+}
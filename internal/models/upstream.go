@@ -0,0 +1,16 @@
+package models
+
+// UpstreamConfig controls timeouts and retries of calls to the Dill APIs.
+// Durations use Go duration syntax ("10s", "500ms"); zero values select the defaults.
+type UpstreamConfig struct {
+	Timeout        string  `json:"timeout,omitempty"`
+	MaxRetries     int     `json:"maxRetries,omitempty"`
+	InitialBackoff string  `json:"initialBackoff,omitempty"`
+	MaxBackoff     string  `json:"maxBackoff,omitempty"`
+	RetryBudget    float64 `json:"retryBudget,omitempty"`
+	// RateLimit is the number of requests per second allowed to each upstream
+	// host, shared by all networks; negative disables rate limiting
+	RateLimit float64 `json:"rateLimit,omitempty"`
+	// RateBurst is the number of requests a host may receive at once
+	RateBurst int `json:"rateBurst,omitempty"`
+}
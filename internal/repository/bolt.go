@@ -0,0 +1,728 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"dill-monitor/internal/models"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Buckets of the bolt database
+var (
+	balancesBucket         = []byte("balances")
+	validatorRewardsBucket = []byte("validator_rewards")
+	// history holds a bucket per address or validator, which holds a bucket
+	// per resolution with the snapshots keyed by time
+	historyBucket = []byte("history")
+	// validator_events holds a bucket per network and validator index with its
+	// events keyed by time
+	validatorEventsBucket = []byte("validator_events")
+)
+
+// historyTiers are the resolutions snapshots are stored at, from finest to coarsest
+var historyTiers = []string{models.ResolutionRaw, models.ResolutionHourly, models.ResolutionDaily}
+
+// BoltRepository implements the Repository interface on an embedded bbolt
+// database, persisting balances and validator rewards across restarts.
+// Metrics operations are no-ops; combine it with a PrometheusRepository
+// through a CompositeRepository to export them.
+type BoltRepository struct {
+	db *bolt.DB
+}
+
+// NewBoltRepository opens or creates the database at path
+func NewBoltRepository(path string) (*BoltRepository, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create database directory: %v", err)
+	}
+
+	// 다른 프로세스가 파일을 잠그고 있으면 무한히 기다리지 않도록 timeout 설정
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database %s: %v", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{balancesBucket, validatorRewardsBucket, historyBucket, validatorEventsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err == nil {
+		err = db.Update(migrateRewardKeys)
+	}
+	if err == nil {
+		err = db.Update(migrateHistoryKeys)
+	}
+	if err == nil {
+		err = db.Update(migrateEventKeys)
+	}
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize database %s: %v", path, err)
+	}
+
+	return &BoltRepository{db: db}, nil
+}
+
+// Close closes the database
+func (r *BoltRepository) Close() error {
+	return r.db.Close()
+}
+
+// rewardKey returns the key of a validator reward. Indices are only unique
+// within a network, so the key includes it.
+func rewardKey(network, validatorIdx string) string {
+	return network + "/" + validatorIdx
+}
+
+// migrateRewardKeys moves validator rewards stored under their index only, by
+// earlier versions, to their network and index. Rewards without a network
+// cannot be assigned and are discarded; they are saved again by the next cycle.
+func migrateRewardKeys(tx *bolt.Tx) error {
+	rewards := tx.Bucket(validatorRewardsBucket)
+	migrated := make(map[string][]byte)
+	var legacy [][]byte
+	err := rewards.ForEach(func(key, value []byte) error {
+		if bytes.IndexByte(key, '/') >= 0 {
+			return nil
+		}
+		legacy = append(legacy, append([]byte(nil), key...))
+		var reward models.ValidatorReward
+		if err := json.Unmarshal(value, &reward); err == nil && reward.Network != "" {
+			migrated[rewardKey(reward.Network, string(key))] = append([]byte(nil), value...)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, key := range legacy {
+		if err := rewards.Delete(key); err != nil {
+			return err
+		}
+	}
+	for key, value := range migrated {
+		if err := rewards.Put([]byte(key), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// put stores v as JSON under key in bucket
+func (r *BoltRepository) put(bucket []byte, key string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return r.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).Put([]byte(key), data)
+	})
+}
+
+// get decodes the JSON stored under key in bucket into v and reports whether it exists
+func (r *BoltRepository) get(bucket []byte, key string, v interface{}) (bool, error) {
+	var data []byte
+	err := r.db.View(func(tx *bolt.Tx) error {
+		// 트랜잭션이 끝나면 값이 유효하지 않으므로 복사
+		if value := tx.Bucket(bucket).Get([]byte(key)); value != nil {
+			data = append([]byte(nil), value...)
+		}
+		return nil
+	})
+	if err != nil || data == nil {
+		return false, err
+	}
+	return true, json.Unmarshal(data, v)
+}
+
+// SaveBalance implements Repository.SaveBalance
+func (r *BoltRepository) SaveBalance(ctx context.Context, balance *models.Balance) error {
+	return r.put(balancesBucket, balance.Address, balance)
+}
+
+// GetBalance implements Repository.GetBalance
+func (r *BoltRepository) GetBalance(ctx context.Context, address string) (*models.Balance, error) {
+	var balance models.Balance
+	exists, err := r.get(balancesBucket, address, &balance)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("balance not found for address: %s", address)
+	}
+	return &balance, nil
+}
+
+// ListBalances implements Repository.ListBalances
+func (r *BoltRepository) ListBalances(ctx context.Context) ([]*models.Balance, error) {
+	var balances []*models.Balance
+	err := r.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(balancesBucket).ForEach(func(key, value []byte) error {
+			var balance models.Balance
+			if err := json.Unmarshal(value, &balance); err != nil {
+				return fmt.Errorf("invalid balance stored for %s: %v", key, err)
+			}
+			balances = append(balances, &balance)
+			return nil
+		})
+	})
+	return balances, err
+}
+
+// UpdateBalance implements Repository.UpdateBalance
+func (r *BoltRepository) UpdateBalance(ctx context.Context, balance *models.Balance) error {
+	return r.SaveBalance(ctx, balance)
+}
+
+// DeleteBalance implements Repository.DeleteBalance. It also deletes the
+// rewards of validators that no other stored balance is backed by.
+func (r *BoltRepository) DeleteBalance(ctx context.Context, address string) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		balances := tx.Bucket(balancesBucket)
+		value := balances.Get([]byte(address))
+		if value == nil {
+			return nil
+		}
+		var balance models.Balance
+		if err := json.Unmarshal(value, &balance); err != nil {
+			return fmt.Errorf("invalid balance stored for %s: %v", address, err)
+		}
+		if err := balances.Delete([]byte(address)); err != nil {
+			return err
+		}
+
+		// 같은 네트워크의 다른 주소에도 설정된 검증자의 보상은 유지
+		inUse := make(map[string]bool)
+		err := balances.ForEach(func(_, value []byte) error {
+			var other models.Balance
+			if err := json.Unmarshal(value, &other); err != nil {
+				return err
+			}
+			for _, validator := range other.KnownValidators() {
+				inUse[rewardKey(other.Network, validator.Index)] = true
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		rewards := tx.Bucket(validatorRewardsBucket)
+		for _, validator := range balance.KnownValidators() {
+			key := rewardKey(balance.Network, validator.Index)
+			if inUse[key] {
+				continue
+			}
+			if err := rewards.Delete([]byte(key)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SaveValidatorReward implements Repository.SaveValidatorReward
+func (r *BoltRepository) SaveValidatorReward(ctx context.Context, reward *models.ValidatorReward) error {
+	return r.put(validatorRewardsBucket, rewardKey(reward.Network, reward.ValidatorIdx), reward)
+}
+
+// GetValidatorReward implements Repository.GetValidatorReward
+func (r *BoltRepository) GetValidatorReward(ctx context.Context, network, validatorIdx string) (*models.ValidatorReward, error) {
+	var reward models.ValidatorReward
+	exists, err := r.get(validatorRewardsBucket, rewardKey(network, validatorIdx), &reward)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("validator reward not found for validator %s on %s", validatorIdx, network)
+	}
+	return &reward, nil
+}
+
+// ListValidatorRewards implements Repository.ListValidatorRewards
+func (r *BoltRepository) ListValidatorRewards(ctx context.Context) ([]*models.ValidatorReward, error) {
+	var rewards []*models.ValidatorReward
+	err := r.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(validatorRewardsBucket).ForEach(func(key, value []byte) error {
+			var reward models.ValidatorReward
+			if err := json.Unmarshal(value, &reward); err != nil {
+				return fmt.Errorf("invalid validator reward stored for %s: %v", key, err)
+			}
+			rewards = append(rewards, &reward)
+			return nil
+		})
+	})
+	return rewards, err
+}
+
+// UpdateValidatorReward implements Repository.UpdateValidatorReward
+func (r *BoltRepository) UpdateValidatorReward(ctx context.Context, reward *models.ValidatorReward) error {
+	return r.SaveValidatorReward(ctx, reward)
+}
+
+// DeleteValidatorReward implements Repository.DeleteValidatorReward
+func (r *BoltRepository) DeleteValidatorReward(ctx context.Context, network, validatorIdx string) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(validatorRewardsBucket).Delete([]byte(rewardKey(network, validatorIdx)))
+	})
+}
+
+// SaveValidatorEvent implements Repository.SaveValidatorEvent
+func (r *BoltRepository) SaveValidatorEvent(ctx context.Context, event *models.ValidatorEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return r.db.Update(func(tx *bolt.Tx) error {
+		validator, err := tx.Bucket(validatorEventsBucket).CreateBucketIfNotExists([]byte(rewardKey(event.Network, event.ValidatorIdx)))
+		if err != nil {
+			return err
+		}
+		return validator.Put(timeKey(event.Time), data)
+	})
+}
+
+// ListValidatorEvents implements Repository.ListValidatorEvents. Events are
+// sorted by time.
+func (r *BoltRepository) ListValidatorEvents(ctx context.Context, query models.EventQuery) ([]*models.ValidatorEvent, error) {
+	var events []*models.ValidatorEvent
+	err := r.forEachEventBucket(query, func(name []byte, validator *bolt.Bucket) error {
+		return validator.ForEach(func(_, value []byte) error {
+			var event models.ValidatorEvent
+			if err := json.Unmarshal(value, &event); err != nil {
+				return fmt.Errorf("invalid event stored for validator %s: %v", name, err)
+			}
+			if query.Matches(&event) {
+				events = append(events, &event)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	models.SortEvents(events)
+	return events, nil
+}
+
+// LatestValidatorEvents implements Repository.LatestValidatorEvents
+func (r *BoltRepository) LatestValidatorEvents(ctx context.Context) ([]*models.ValidatorEvent, error) {
+	var events []*models.ValidatorEvent
+	err := r.forEachEventBucket(models.EventQuery{}, func(name []byte, validator *bolt.Bucket) error {
+		_, value := validator.Cursor().Last()
+		if value == nil {
+			return nil
+		}
+		var event models.ValidatorEvent
+		if err := json.Unmarshal(value, &event); err != nil {
+			return fmt.Errorf("invalid event stored for validator %s: %v", name, err)
+		}
+		events = append(events, &event)
+		return nil
+	})
+	return events, err
+}
+
+// forEachEventBucket calls fn with the event bucket of every validator that
+// may hold events selected by query
+func (r *BoltRepository) forEachEventBucket(query models.EventQuery, fn func(name []byte, validator *bolt.Bucket) error) error {
+	return r.db.View(func(tx *bolt.Tx) error {
+		events := tx.Bucket(validatorEventsBucket)
+		// 네트워크와 인덱스가 모두 주어지면 해당 버킷만 조회
+		if query.Network != "" && query.ValidatorIdx != "" {
+			name := []byte(rewardKey(query.Network, query.ValidatorIdx))
+			if validator := events.Bucket(name); validator != nil {
+				return fn(name, validator)
+			}
+			return nil
+		}
+
+		return events.ForEach(func(name, value []byte) error {
+			if value != nil {
+				return nil
+			}
+			return fn(name, events.Bucket(name))
+		})
+	})
+}
+
+// migrateEventKeys moves the events of buckets named by validator index only,
+// by earlier versions, to the bucket of their network and index. Events
+// without a network cannot be assigned and are discarded.
+func migrateEventKeys(tx *bolt.Tx) error {
+	events := tx.Bucket(validatorEventsBucket)
+	var legacy [][]byte
+	err := events.ForEach(func(key, value []byte) error {
+		if value == nil && bytes.IndexByte(key, '/') < 0 {
+			legacy = append(legacy, append([]byte(nil), key...))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, name := range legacy {
+		type entry struct {
+			key, value []byte
+			event      models.ValidatorEvent
+		}
+		var entries []entry
+		err := events.Bucket(name).ForEach(func(key, value []byte) error {
+			e := entry{key: append([]byte(nil), key...), value: append([]byte(nil), value...)}
+			if err := json.Unmarshal(value, &e.event); err == nil && e.event.Network != "" {
+				entries = append(entries, e)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if err := events.DeleteBucket(name); err != nil {
+			return err
+		}
+
+		for _, e := range entries {
+			validator, err := events.CreateBucketIfNotExists([]byte(rewardKey(e.event.Network, e.event.ValidatorIdx)))
+			if err != nil {
+				return err
+			}
+			if err := validator.Put(e.key, e.value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// historyKey returns the name of the history bucket of an address or
+// validator on a network
+func historyKey(subject, network, key string) []byte {
+	// 주소는 대소문자 구분 없이 같은 이력을 사용
+	if subject == models.SubjectAddress {
+		key = strings.ToLower(key)
+	}
+	return []byte(subject + ":" + network + ":" + key)
+}
+
+// migrateHistoryKeys moves the snapshots of history buckets named without a
+// network, by earlier versions, to the buckets of the network of every snapshot
+func migrateHistoryKeys(tx *bolt.Tx) error {
+	history := tx.Bucket(historyBucket)
+	var legacy [][]byte
+	err := history.ForEach(func(key, value []byte) error {
+		if value == nil && bytes.Count(key, []byte(":")) == 1 {
+			legacy = append(legacy, append([]byte(nil), key...))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, name := range legacy {
+		type entry struct {
+			tier, key, value []byte
+			snapshot         models.Snapshot
+		}
+		var entries []entry
+		old := history.Bucket(name)
+		for _, tier := range historyTiers {
+			bucket := old.Bucket([]byte(tier))
+			if bucket == nil {
+				continue
+			}
+			err := bucket.ForEach(func(key, value []byte) error {
+				e := entry{tier: []byte(tier), key: append([]byte(nil), key...), value: append([]byte(nil), value...)}
+				if err := json.Unmarshal(value, &e.snapshot); err != nil {
+					return fmt.Errorf("invalid snapshot stored for %s: %v", name, err)
+				}
+				entries = append(entries, e)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if err := history.DeleteBucket(name); err != nil {
+			return err
+		}
+
+		for _, e := range entries {
+			subject, err := history.CreateBucketIfNotExists(historyKey(e.snapshot.Subject, e.snapshot.Network, e.snapshot.Key))
+			if err != nil {
+				return err
+			}
+			tier, err := subject.CreateBucketIfNotExists(e.tier)
+			if err != nil {
+				return err
+			}
+			if err := tier.Put(e.key, e.value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// timeKey encodes t so that keys sort by time
+func timeKey(t time.Time) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(t.UnixNano()))
+	return key
+}
+
+// SaveSnapshots implements Repository.SaveSnapshots. Snapshots are stored at
+// raw resolution until CompactHistory downsamples them.
+func (r *BoltRepository) SaveSnapshots(ctx context.Context, snapshots []*models.Snapshot) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		for _, snapshot := range snapshots {
+			data, err := json.Marshal(snapshot)
+			if err != nil {
+				return err
+			}
+			subject, err := tx.Bucket(historyBucket).CreateBucketIfNotExists(historyKey(snapshot.Subject, snapshot.Network, snapshot.Key))
+			if err != nil {
+				return err
+			}
+			raw, err := subject.CreateBucketIfNotExists([]byte(models.ResolutionRaw))
+			if err != nil {
+				return err
+			}
+			if err := raw.Put(timeKey(snapshot.Time), data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// QueryHistory implements Repository.QueryHistory. Snapshots of every stored
+// resolution within the range are merged and downsampled to the requested one.
+func (r *BoltRepository) QueryHistory(ctx context.Context, query models.HistoryQuery) ([]*models.Snapshot, error) {
+	period, err := models.ResolutionPeriod(query.Resolution)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []*models.Snapshot
+	err = r.db.View(func(tx *bolt.Tx) error {
+		subject := tx.Bucket(historyBucket).Bucket(historyKey(query.Subject, query.Network, query.Key))
+		if subject == nil {
+			return nil
+		}
+		for _, tier := range historyTiers {
+			bucket := subject.Bucket([]byte(tier))
+			if bucket == nil {
+				continue
+			}
+			c := bucket.Cursor()
+			k, v := c.First()
+			if !query.From.IsZero() {
+				// 다운샘플링된 값은 구간 시작 시각을 키로 가지므로 구간 시작부터 탐색
+				tierPeriod, _ := models.ResolutionPeriod(tier)
+				k, v = c.Seek(timeKey(query.From.Truncate(tierPeriod)))
+			}
+			for ; k != nil; k, v = c.Next() {
+				if !query.To.IsZero() && bytes.Compare(k, timeKey(query.To)) > 0 {
+					break
+				}
+				var snapshot models.Snapshot
+				if err := json.Unmarshal(v, &snapshot); err != nil {
+					return fmt.Errorf("invalid snapshot stored for %s: %v", historyKey(query.Subject, query.Network, query.Key), err)
+				}
+				if snapshot.Time.Before(query.From) || (!query.To.IsZero() && snapshot.Time.After(query.To)) {
+					continue
+				}
+				snapshots = append(snapshots, &snapshot)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	models.SortSnapshots(snapshots)
+	return models.Downsample(snapshots, period), nil
+}
+
+// CompactHistory implements Repository.CompactHistory. Raw snapshots older
+// than the raw retention are downsampled to hourly ones, hourly snapshots
+// older than the hourly retention to daily ones, and daily snapshots older
+// than the daily retention are deleted.
+func (r *BoltRepository) CompactHistory(ctx context.Context, policy models.RetentionPolicy, now time.Time) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		history := tx.Bucket(historyBucket)
+		var subjects [][]byte
+		err := history.ForEach(func(key, value []byte) error {
+			if value == nil {
+				subjects = append(subjects, append([]byte(nil), key...))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, name := range subjects {
+			subject := history.Bucket(name)
+			if policy.Raw > 0 {
+				// 완료된 구간만 다운샘플링되도록 기준 시각을 구간 단위로 내림
+				cutoff := now.Add(-policy.Raw).Truncate(time.Hour)
+				if err := downsampleTier(subject, models.ResolutionRaw, models.ResolutionHourly, time.Hour, cutoff); err != nil {
+					return fmt.Errorf("failed to compact history of %s: %v", name, err)
+				}
+			}
+			if policy.Hourly > 0 {
+				cutoff := now.Add(-policy.Hourly).Truncate(24 * time.Hour)
+				if err := downsampleTier(subject, models.ResolutionHourly, models.ResolutionDaily, 24*time.Hour, cutoff); err != nil {
+					return fmt.Errorf("failed to compact history of %s: %v", name, err)
+				}
+			}
+			if policy.Daily > 0 {
+				if err := downsampleTier(subject, models.ResolutionDaily, "", 0, now.Add(-policy.Daily)); err != nil {
+					return fmt.Errorf("failed to compact history of %s: %v", name, err)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// downsampleTier moves the snapshots of the from tier older than cutoff into
+// the to tier, keeping the latest snapshot of every period keyed by the start
+// of the period. Without a to tier the snapshots are deleted.
+func downsampleTier(subject *bolt.Bucket, from, to string, period time.Duration, cutoff time.Time) error {
+	source := subject.Bucket([]byte(from))
+	if source == nil {
+		return nil
+	}
+
+	var keys [][]byte
+	var snapshots []*models.Snapshot
+	c := source.Cursor()
+	for k, v := c.First(); k != nil && bytes.Compare(k, timeKey(cutoff)) < 0; k, v = c.Next() {
+		keys = append(keys, append([]byte(nil), k...))
+		if to == "" {
+			continue
+		}
+		var snapshot models.Snapshot
+		if err := json.Unmarshal(v, &snapshot); err != nil {
+			return err
+		}
+		snapshots = append(snapshots, &snapshot)
+	}
+
+	if to != "" && len(snapshots) > 0 {
+		target, err := subject.CreateBucketIfNotExists([]byte(to))
+		if err != nil {
+			return err
+		}
+		for _, snapshot := range models.Downsample(snapshots, period) {
+			key := timeKey(snapshot.Time.Truncate(period))
+			// 같은 구간의 값이 이미 있으면 더 최신 값을 유지
+			if existing := target.Get(key); existing != nil {
+				var current models.Snapshot
+				if err := json.Unmarshal(existing, &current); err == nil && current.Time.After(snapshot.Time) {
+					continue
+				}
+			}
+			data, err := json.Marshal(snapshot)
+			if err != nil {
+				return err
+			}
+			if err := target.Put(key, data); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, key := range keys {
+		if err := source.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecordBalanceMetric implements Repository.RecordBalanceMetric
+func (r *BoltRepository) RecordBalanceMetric(balance *models.Balance) error { return nil }
+
+// RecordValidatorRewardMetric implements Repository.RecordValidatorRewardMetric
+func (r *BoltRepository) RecordValidatorRewardMetric(reward *models.ValidatorReward) error {
+	return nil
+}
+
+// RecordAPIMetric implements Repository.RecordAPIMetric
+func (r *BoltRepository) RecordAPIMetric(network, endpoint, method string, duration float64, status int) error {
+	return nil
+}
+
+// RecordAPIError implements Repository.RecordAPIError
+func (r *BoltRepository) RecordAPIError(network, endpoint, method, errorType string) error {
+	return nil
+}
+
+// RecordUpstreamRetry implements Repository.RecordUpstreamRetry
+func (r *BoltRepository) RecordUpstreamRetry(network, endpoint string) error { return nil }
+
+// RecordUpstreamFailure implements Repository.RecordUpstreamFailure
+func (r *BoltRepository) RecordUpstreamFailure(network, endpoint string) error { return nil }
+
+// RecordScrape implements Repository.RecordScrape
+func (r *BoltRepository) RecordScrape(address, label, network, source string, success bool, duration float64) error {
+	return nil
+}
+
+// RecordNextRun implements Repository.RecordNextRun
+func (r *BoltRepository) RecordNextRun(address, label, network string, next time.Time) error {
+	return nil
+}
+
+// RecordRateLimitWait implements Repository.RecordRateLimitWait
+func (r *BoltRepository) RecordRateLimitWait(host string, wait float64) error { return nil }
+
+// RecordQueueDepth implements Repository.RecordQueueDepth
+func (r *BoltRepository) RecordQueueDepth(depth int) error { return nil }
+
+// RecordCycleDuration implements Repository.RecordCycleDuration
+func (r *BoltRepository) RecordCycleDuration(duration float64) error { return nil }
+
+// RecordCycleSkipped implements Repository.RecordCycleSkipped
+func (r *BoltRepository) RecordCycleSkipped() error { return nil }
+
+// RecordValidatorDiscovered implements Repository.RecordValidatorDiscovered
+func (r *BoltRepository) RecordValidatorDiscovered(address, label, network string) error {
+	return nil
+}
+
+// RecordConfigReload implements Repository.RecordConfigReload
+func (r *BoltRepository) RecordConfigReload(success bool, at time.Time) error { return nil }
+
+// UpdateAlertThresholds implements Repository.UpdateAlertThresholds
+func (r *BoltRepository) UpdateAlertThresholds(thresholds map[string]float64) error { return nil }
+
+// PruneSeries implements Repository.PruneSeries
+func (r *BoltRepository) PruneSeries(ctx context.Context, addresses []models.Address) (int, error) {
+	return 0, nil
+}
+
+// UpdateSummaryMetrics implements Repository.UpdateSummaryMetrics
+func (r *BoltRepository) UpdateSummaryMetrics(ctx context.Context, balances []*models.Balance) error {
+	return nil
+}
@@ -0,0 +1,228 @@
+package repository
+
+import (
+	"context"
+	"dill-monitor/internal/models"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// newTestBolt opens a bolt repository in a temporary directory
+func newTestBolt(t *testing.T) (*BoltRepository, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "test.db")
+	repo, err := NewBoltRepository(path)
+	if err != nil {
+		t.Fatalf("NewBoltRepository: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	return repo, path
+}
+
+// validatorSnapshots returns snapshots of a validator, one every interval
+// from start, with the staking balance counting them
+func validatorSnapshots(network string, start time.Time, interval time.Duration, n int) []*models.Snapshot {
+	snapshots := make([]*models.Snapshot, 0, n)
+	for i := 0; i < n; i++ {
+		snapshots = append(snapshots, &models.Snapshot{
+			Time:           start.Add(time.Duration(i) * interval),
+			Subject:        models.SubjectValidator,
+			Key:            "17021",
+			Network:        network,
+			StakingBalance: float64(i),
+		})
+	}
+	return snapshots
+}
+
+// stakingBalances returns the staking balances of snapshots
+func stakingBalances(snapshots []*models.Snapshot) []float64 {
+	var balances []float64
+	for _, snapshot := range snapshots {
+		balances = append(balances, snapshot.StakingBalance)
+	}
+	return balances
+}
+
+func TestBoltQueryHistory(t *testing.T) {
+	repo, _ := newTestBolt(t)
+	ctx := context.Background()
+	start := time.Date(2025, 5, 19, 0, 0, 0, 0, time.UTC)
+
+	// 6시간 동안 30분마다 alps 스냅샷, andes에는 같은 인덱스의 다른 검증자
+	snapshots := validatorSnapshots("alps", start, 30*time.Minute, 12)
+	for _, snapshot := range validatorSnapshots("andes", start, time.Hour, 2) {
+		snapshot.StakingBalance += 100
+		snapshots = append(snapshots, snapshot)
+	}
+	snapshots = append(snapshots, &models.Snapshot{Time: start, Subject: models.SubjectAddress, Key: "0xABC", Network: "alps", Balance: 1})
+	if err := repo.SaveSnapshots(ctx, snapshots); err != nil {
+		t.Fatalf("SaveSnapshots: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		query models.HistoryQuery
+		want  []float64
+	}{
+		{
+			name:  "raw range",
+			query: models.HistoryQuery{Subject: models.SubjectValidator, Key: "17021", Network: "alps", From: start.Add(time.Hour), To: start.Add(2 * time.Hour)},
+			want:  []float64{2, 3, 4},
+		},
+		{
+			name:  "hourly",
+			query: models.HistoryQuery{Subject: models.SubjectValidator, Key: "17021", Network: "alps", From: start, To: start.Add(3 * time.Hour), Resolution: models.ResolutionHourly},
+			want:  []float64{1, 3, 5, 6},
+		},
+		{
+			name:  "daily",
+			query: models.HistoryQuery{Subject: models.SubjectValidator, Key: "17021", Network: "alps", Resolution: models.ResolutionDaily},
+			want:  []float64{11},
+		},
+		{
+			name:  "other network",
+			query: models.HistoryQuery{Subject: models.SubjectValidator, Key: "17021", Network: "andes"},
+			want:  []float64{100, 101},
+		},
+		{
+			name:  "unknown validator",
+			query: models.HistoryQuery{Subject: models.SubjectValidator, Key: "1", Network: "alps"},
+		},
+		{
+			name:  "address case insensitive",
+			query: models.HistoryQuery{Subject: models.SubjectAddress, Key: "0xabc", Network: "alps"},
+			want:  []float64{0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.QueryHistory(ctx, tt.query)
+			if err != nil {
+				t.Fatalf("QueryHistory: %v", err)
+			}
+			if balances := stakingBalances(got); !reflect.DeepEqual(balances, tt.want) {
+				t.Errorf("staking balances = %v, want %v", balances, tt.want)
+			}
+		})
+	}
+}
+
+func TestBoltCompactHistory(t *testing.T) {
+	start := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	// 10일 동안 30분마다 저장된 스냅샷
+	snapshots := validatorSnapshots("alps", start, 30*time.Minute, 10*48)
+	now := start.Add(10 * 24 * time.Hour)
+
+	tests := []struct {
+		name   string
+		policy models.RetentionPolicy
+		// raw, hourly and daily are the number of snapshots expected in every tier
+		raw, hourly, daily int
+	}{
+		{"keep everything", models.RetentionPolicy{}, 480, 0, 0},
+		{"raw for a day", models.RetentionPolicy{Raw: 24 * time.Hour}, 48, 216, 0},
+		{"hourly for three days", models.RetentionPolicy{Raw: 24 * time.Hour, Hourly: 3 * 24 * time.Hour}, 48, 48, 7},
+		{"daily for five days", models.RetentionPolicy{Raw: 24 * time.Hour, Hourly: 3 * 24 * time.Hour, Daily: 5 * 24 * time.Hour}, 48, 48, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, _ := newTestBolt(t)
+			ctx := context.Background()
+			if err := repo.SaveSnapshots(ctx, snapshots); err != nil {
+				t.Fatalf("SaveSnapshots: %v", err)
+			}
+			if err := repo.CompactHistory(ctx, tt.policy, now); err != nil {
+				t.Fatalf("CompactHistory: %v", err)
+			}
+			// 두 번 실행해도 결과가 같아야 함
+			if err := repo.CompactHistory(ctx, tt.policy, now); err != nil {
+				t.Fatalf("second CompactHistory: %v", err)
+			}
+
+			counts := make(map[string]int)
+			err := repo.db.View(func(tx *bolt.Tx) error {
+				subject := tx.Bucket(historyBucket).Bucket(historyKey(models.SubjectValidator, "alps", "17021"))
+				for _, tier := range historyTiers {
+					if bucket := subject.Bucket([]byte(tier)); bucket != nil {
+						counts[tier] = bucket.Stats().KeyN
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := map[string]int{models.ResolutionRaw: tt.raw, models.ResolutionHourly: tt.hourly, models.ResolutionDaily: tt.daily}
+			for tier, n := range want {
+				if counts[tier] != n {
+					t.Errorf("%s snapshots = %d, want %d", tier, counts[tier], n)
+				}
+			}
+
+			// 다운샘플링 후에도 최신 값은 그대로 조회됨
+			latest, err := repo.QueryHistory(ctx, models.HistoryQuery{Subject: models.SubjectValidator, Key: "17021", Network: "alps", Resolution: models.ResolutionDaily})
+			if err != nil {
+				t.Fatalf("QueryHistory: %v", err)
+			}
+			if len(latest) == 0 || latest[len(latest)-1].StakingBalance != 479 {
+				t.Errorf("latest daily snapshot = %v, want staking balance 479", stakingBalances(latest))
+			}
+		})
+	}
+}
+
+func TestBoltMigrateHistoryKeys(t *testing.T) {
+	repo, path := newTestBolt(t)
+	at := time.Date(2025, 5, 19, 0, 0, 0, 0, time.UTC)
+
+	// 네트워크 없이 저장하던 이전 버전의 버킷
+	err := repo.db.Update(func(tx *bolt.Tx) error {
+		subject, err := tx.Bucket(historyBucket).CreateBucket([]byte("validator:17021"))
+		if err != nil {
+			return err
+		}
+		raw, err := subject.CreateBucket([]byte(models.ResolutionRaw))
+		if err != nil {
+			return err
+		}
+		for i, network := range []string{"alps", "andes"} {
+			data, err := json.Marshal(&models.Snapshot{Time: at.Add(time.Duration(i) * time.Minute), Subject: models.SubjectValidator, Key: "17021", Network: network})
+			if err != nil {
+				return err
+			}
+			if err := raw.Put(timeKey(at.Add(time.Duration(i)*time.Minute)), data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo.Close()
+
+	repo, err = NewBoltRepository(path)
+	if err != nil {
+		t.Fatalf("reopening: %v", err)
+	}
+	defer repo.Close()
+
+	for _, network := range []string{"alps", "andes"} {
+		snapshots, err := repo.QueryHistory(context.Background(), models.HistoryQuery{Subject: models.SubjectValidator, Key: "17021", Network: network})
+		if err != nil {
+			t.Fatalf("QueryHistory: %v", err)
+		}
+		if len(snapshots) != 1 || snapshots[0].Network != network {
+			t.Errorf("snapshots on %s = %+v, want the one migrated snapshot", network, snapshots)
+		}
+	}
+}
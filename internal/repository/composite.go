@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"dill-monitor/internal/models"
+	"fmt"
+	"time"
+)
+
+// CompositeRepository implements the Repository interface on top of a storage
+// repository and a metrics repository. Writes go to both so that the exported
+// metrics and the stored history stay in sync; reads are served by storage.
+type CompositeRepository struct {
+	storage Repository
+	metrics Repository
+}
+
+// NewCompositeRepository combines a storage and a metrics repository
+func NewCompositeRepository(storage, metrics Repository) *CompositeRepository {
+	return &CompositeRepository{storage: storage, metrics: metrics}
+}
+
+// both applies a write to storage and then to metrics. The metrics are updated
+// even if storage fails, so that they keep following the live data.
+func (r *CompositeRepository) both(write func(repo Repository) error) error {
+	storageErr := write(r.storage)
+	metricsErr := write(r.metrics)
+	if storageErr != nil {
+		return fmt.Errorf("storage: %v", storageErr)
+	}
+	return metricsErr
+}
+
+// Restore exports the balances, the latest event of every validator and the
+// validator rewards kept in storage, so that metrics are available right
+// after a restart, before the first cycle ends
+func (r *CompositeRepository) Restore(ctx context.Context) (int, error) {
+	balances, err := r.storage.ListBalances(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, balance := range balances {
+		if err := r.metrics.SaveBalance(ctx, balance); err != nil {
+			return 0, err
+		}
+	}
+
+	// 검증자 메트릭보다 먼저 복원해 현재 상태의 시작 시각이 함께 내보내지도록 함.
+	// 메트릭에는 검증자별 최신 이벤트만 필요
+	events, err := r.storage.LatestValidatorEvents(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, event := range events {
+		if err := r.metrics.SaveValidatorEvent(ctx, event); err != nil {
+			return 0, err
+		}
+	}
+
+	rewards, err := r.storage.ListValidatorRewards(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, reward := range rewards {
+		if err := r.metrics.SaveValidatorReward(ctx, reward); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := r.metrics.UpdateSummaryMetrics(ctx, balances); err != nil {
+		return 0, err
+	}
+	return len(balances), nil
+}
+
+// SaveBalance implements Repository.SaveBalance
+func (r *CompositeRepository) SaveBalance(ctx context.Context, balance *models.Balance) error {
+	return r.both(func(repo Repository) error { return repo.SaveBalance(ctx, balance) })
+}
+
+// GetBalance implements Repository.GetBalance
+func (r *CompositeRepository) GetBalance(ctx context.Context, address string) (*models.Balance, error) {
+	return r.storage.GetBalance(ctx, address)
+}
+
+// ListBalances implements Repository.ListBalances
+func (r *CompositeRepository) ListBalances(ctx context.Context) ([]*models.Balance, error) {
+	return r.storage.ListBalances(ctx)
+}
+
+// UpdateBalance implements Repository.UpdateBalance
+func (r *CompositeRepository) UpdateBalance(ctx context.Context, balance *models.Balance) error {
+	return r.both(func(repo Repository) error { return repo.UpdateBalance(ctx, balance) })
+}
+
+// DeleteBalance implements Repository.DeleteBalance
+func (r *CompositeRepository) DeleteBalance(ctx context.Context, address string) error {
+	return r.both(func(repo Repository) error { return repo.DeleteBalance(ctx, address) })
+}
+
+// SaveValidatorReward implements Repository.SaveValidatorReward
+func (r *CompositeRepository) SaveValidatorReward(ctx context.Context, reward *models.ValidatorReward) error {
+	return r.both(func(repo Repository) error { return repo.SaveValidatorReward(ctx, reward) })
+}
+
+// GetValidatorReward implements Repository.GetValidatorReward
+func (r *CompositeRepository) GetValidatorReward(ctx context.Context, network, validatorIdx string) (*models.ValidatorReward, error) {
+	return r.storage.GetValidatorReward(ctx, network, validatorIdx)
+}
+
+// ListValidatorRewards implements Repository.ListValidatorRewards
+func (r *CompositeRepository) ListValidatorRewards(ctx context.Context) ([]*models.ValidatorReward, error) {
+	return r.storage.ListValidatorRewards(ctx)
+}
+
+// UpdateValidatorReward implements Repository.UpdateValidatorReward
+func (r *CompositeRepository) UpdateValidatorReward(ctx context.Context, reward *models.ValidatorReward) error {
+	return r.both(func(repo Repository) error { return repo.UpdateValidatorReward(ctx, reward) })
+}
+
+// DeleteValidatorReward implements Repository.DeleteValidatorReward
+func (r *CompositeRepository) DeleteValidatorReward(ctx context.Context, network, validatorIdx string) error {
+	return r.both(func(repo Repository) error { return repo.DeleteValidatorReward(ctx, network, validatorIdx) })
+}
+
+// SaveValidatorEvent implements Repository.SaveValidatorEvent
+func (r *CompositeRepository) SaveValidatorEvent(ctx context.Context, event *models.ValidatorEvent) error {
+	return r.both(func(repo Repository) error { return repo.SaveValidatorEvent(ctx, event) })
+}
+
+// ListValidatorEvents implements Repository.ListValidatorEvents
+func (r *CompositeRepository) ListValidatorEvents(ctx context.Context, query models.EventQuery) ([]*models.ValidatorEvent, error) {
+	return r.storage.ListValidatorEvents(ctx, query)
+}
+
+// LatestValidatorEvents implements Repository.LatestValidatorEvents
+func (r *CompositeRepository) LatestValidatorEvents(ctx context.Context) ([]*models.ValidatorEvent, error) {
+	return r.storage.LatestValidatorEvents(ctx)
+}
+
+// SaveSnapshots implements Repository.SaveSnapshots
+func (r *CompositeRepository) SaveSnapshots(ctx context.Context, snapshots []*models.Snapshot) error {
+	return r.both(func(repo Repository) error { return repo.SaveSnapshots(ctx, snapshots) })
+}
+
+// QueryHistory implements Repository.QueryHistory
+func (r *CompositeRepository) QueryHistory(ctx context.Context, query models.HistoryQuery) ([]*models.Snapshot, error) {
+	return r.storage.QueryHistory(ctx, query)
+}
+
+// CompactHistory implements Repository.CompactHistory
+func (r *CompositeRepository) CompactHistory(ctx context.Context, policy models.RetentionPolicy, now time.Time) error {
+	return r.storage.CompactHistory(ctx, policy, now)
+}
+
+// RecordBalanceMetric implements Repository.RecordBalanceMetric
+func (r *CompositeRepository) RecordBalanceMetric(balance *models.Balance) error {
+	return r.metrics.RecordBalanceMetric(balance)
+}
+
+// RecordValidatorRewardMetric implements Repository.RecordValidatorRewardMetric
+func (r *CompositeRepository) RecordValidatorRewardMetric(reward *models.ValidatorReward) error {
+	return r.metrics.RecordValidatorRewardMetric(reward)
+}
+
+// RecordAPIMetric implements Repository.RecordAPIMetric
+func (r *CompositeRepository) RecordAPIMetric(network, endpoint, method string, duration float64, status int) error {
+	return r.metrics.RecordAPIMetric(network, endpoint, method, duration, status)
+}
+
+// RecordAPIError implements Repository.RecordAPIError
+func (r *CompositeRepository) RecordAPIError(network, endpoint, method, errorType string) error {
+	return r.metrics.RecordAPIError(network, endpoint, method, errorType)
+}
+
+// RecordUpstreamRetry implements Repository.RecordUpstreamRetry
+func (r *CompositeRepository) RecordUpstreamRetry(network, endpoint string) error {
+	return r.metrics.RecordUpstreamRetry(network, endpoint)
+}
+
+// RecordUpstreamFailure implements Repository.RecordUpstreamFailure
+func (r *CompositeRepository) RecordUpstreamFailure(network, endpoint string) error {
+	return r.metrics.RecordUpstreamFailure(network, endpoint)
+}
+
+// RecordScrape implements Repository.RecordScrape
+func (r *CompositeRepository) RecordScrape(address, label, network, source string, success bool, duration float64) error {
+	return r.metrics.RecordScrape(address, label, network, source, success, duration)
+}
+
+// RecordNextRun implements Repository.RecordNextRun
+func (r *CompositeRepository) RecordNextRun(address, label, network string, next time.Time) error {
+	return r.metrics.RecordNextRun(address, label, network, next)
+}
+
+// RecordRateLimitWait implements Repository.RecordRateLimitWait
+func (r *CompositeRepository) RecordRateLimitWait(host string, wait float64) error {
+	return r.metrics.RecordRateLimitWait(host, wait)
+}
+
+// RecordQueueDepth implements Repository.RecordQueueDepth
+func (r *CompositeRepository) RecordQueueDepth(depth int) error {
+	return r.metrics.RecordQueueDepth(depth)
+}
+
+// RecordCycleDuration implements Repository.RecordCycleDuration
+func (r *CompositeRepository) RecordCycleDuration(duration float64) error {
+	return r.metrics.RecordCycleDuration(duration)
+}
+
+// RecordCycleSkipped implements Repository.RecordCycleSkipped
+func (r *CompositeRepository) RecordCycleSkipped() error {
+	return r.metrics.RecordCycleSkipped()
+}
+
+// RecordValidatorDiscovered implements Repository.RecordValidatorDiscovered
+func (r *CompositeRepository) RecordValidatorDiscovered(address, label, network string) error {
+	return r.metrics.RecordValidatorDiscovered(address, label, network)
+}
+
+// RecordConfigReload implements Repository.RecordConfigReload
+func (r *CompositeRepository) RecordConfigReload(success bool, at time.Time) error {
+	return r.metrics.RecordConfigReload(success, at)
+}
+
+// UpdateAlertThresholds implements Repository.UpdateAlertThresholds
+func (r *CompositeRepository) UpdateAlertThresholds(thresholds map[string]float64) error {
+	return r.metrics.UpdateAlertThresholds(thresholds)
+}
+
+// PruneSeries implements Repository.PruneSeries
+func (r *CompositeRepository) PruneSeries(ctx context.Context, addresses []models.Address) (int, error) {
+	return r.metrics.PruneSeries(ctx, addresses)
+}
+
+// UpdateSummaryMetrics implements Repository.UpdateSummaryMetrics
+func (r *CompositeRepository) UpdateSummaryMetrics(ctx context.Context, balances []*models.Balance) error {
+	return r.metrics.UpdateSummaryMetrics(ctx, balances)
+}
@@ -3,8 +3,13 @@ package repository
 import (
 	"context"
 	"dill-monitor/internal/models"
+	"errors"
+	"time"
 )
 
+// ErrHistoryUnavailable is returned by repositories that keep no history
+var ErrHistoryUnavailable = errors.New("history is not available without persistent storage")
+
 // Repository defines the interface for data storage and retrieval
 type Repository interface {
 	// Balance operations
@@ -16,15 +21,40 @@ type Repository interface {
 
 	// Validator reward operations
 	SaveValidatorReward(ctx context.Context, reward *models.ValidatorReward) error
-	GetValidatorReward(ctx context.Context, validatorIdx string) (*models.ValidatorReward, error)
+	GetValidatorReward(ctx context.Context, network, validatorIdx string) (*models.ValidatorReward, error)
 	ListValidatorRewards(ctx context.Context) ([]*models.ValidatorReward, error)
 	UpdateValidatorReward(ctx context.Context, reward *models.ValidatorReward) error
-	DeleteValidatorReward(ctx context.Context, validatorIdx string) error
+	DeleteValidatorReward(ctx context.Context, network, validatorIdx string) error
+
+	// Validator event operations
+	SaveValidatorEvent(ctx context.Context, event *models.ValidatorEvent) error
+	ListValidatorEvents(ctx context.Context, query models.EventQuery) ([]*models.ValidatorEvent, error)
+	LatestValidatorEvents(ctx context.Context) ([]*models.ValidatorEvent, error)
+
+	// History operations
+	SaveSnapshots(ctx context.Context, snapshots []*models.Snapshot) error
+	QueryHistory(ctx context.Context, query models.HistoryQuery) ([]*models.Snapshot, error)
+	CompactHistory(ctx context.Context, policy models.RetentionPolicy, now time.Time) error
 
 	// Metrics operations
 	RecordBalanceMetric(balance *models.Balance) error
 	RecordValidatorRewardMetric(reward *models.ValidatorReward) error
-	RecordAPIMetric(endpoint string, duration float64, status int) error
+	RecordAPIMetric(network, endpoint, method string, duration float64, status int) error
+	RecordAPIError(network, endpoint, method, errorType string) error
+	RecordUpstreamRetry(network, endpoint string) error
+	RecordUpstreamFailure(network, endpoint string) error
+	RecordScrape(address, label, network, source string, success bool, duration float64) error
+	RecordNextRun(address, label, network string, next time.Time) error
+	RecordRateLimitWait(host string, wait float64) error
+	RecordQueueDepth(depth int) error
+	RecordCycleDuration(duration float64) error
+	RecordCycleSkipped() error
+	RecordValidatorDiscovered(address, label, network string) error
+	RecordConfigReload(success bool, at time.Time) error
+	UpdateAlertThresholds(thresholds map[string]float64) error
+	// PruneSeries deletes the series not exported for the given addresses, with
+	// their network resolved, and returns how many label sets were deleted
+	PruneSeries(ctx context.Context, addresses []models.Address) (int, error)
 
 	// Summary metrics operations
 	UpdateSummaryMetrics(ctx context.Context, balances []*models.Balance) error
@@ -12,22 +12,81 @@ import (
 	"time"
 )
 
+// sources lists every source an address is scraped from
+var sources = []string{
+	models.SourceAddress,
+	models.SourceBalance,
+	models.SourceStaker,
+	models.SourceValidator,
+	models.SourceValidatorDetail,
+}
+
+// seriesLabels are the label values an address or validator was exported with
+type seriesLabels struct {
+	label   string
+	network string
+}
+
 // PrometheusRepository implements the Repository interface using Prometheus
 type PrometheusRepository struct {
 	client *metrics.PrometheusClient
 	// 메모리 내 저장소: 최근 업데이트된 밸런스 정보를 저장
 	balances      map[string]*models.Balance
 	balancesMutex sync.RWMutex
+
+	// 삭제할 수 있도록 주소, 검증자별로 내보낸 라벨 값을 기록
+	addressSeries   map[string]map[seriesLabels]bool
+	validatorSeries map[string]map[seriesLabels]bool
+	summaryNetworks map[string]bool
+	seriesMutex     sync.Mutex
+
+	// 네트워크, 검증자별 최신 상태 변경 이벤트만 메모리에 유지
+	latestEvents map[eventKey]*models.ValidatorEvent
+	eventsMutex  sync.RWMutex
+}
+
+// eventKey identifies the events of a validator on a network
+type eventKey struct {
+	validatorIdx string
+	network      string
 }
 
 // NewPrometheusRepository creates a new Prometheus repository
 func NewPrometheusRepository(client *metrics.PrometheusClient) *PrometheusRepository {
 	return &PrometheusRepository{
-		client:   client,
-		balances: make(map[string]*models.Balance),
+		client:          client,
+		balances:        make(map[string]*models.Balance),
+		addressSeries:   make(map[string]map[seriesLabels]bool),
+		validatorSeries: make(map[string]map[seriesLabels]bool),
+		summaryNetworks: make(map[string]bool),
+		latestEvents:    make(map[eventKey]*models.ValidatorEvent),
 	}
 }
 
+// trackSeries records that series were exported for key with the given labels
+func (r *PrometheusRepository) trackSeries(series map[string]map[seriesLabels]bool, key, label, network string) {
+	r.seriesMutex.Lock()
+	defer r.seriesMutex.Unlock()
+
+	if series[key] == nil {
+		series[key] = make(map[seriesLabels]bool)
+	}
+	series[key][seriesLabels{label, network}] = true
+}
+
+// untrackSeries forgets and returns the labels series were exported with for key
+func (r *PrometheusRepository) untrackSeries(series map[string]map[seriesLabels]bool, key string) []seriesLabels {
+	r.seriesMutex.Lock()
+	defer r.seriesMutex.Unlock()
+
+	var labels []seriesLabels
+	for l := range series[key] {
+		labels = append(labels, l)
+	}
+	delete(series, key)
+	return labels
+}
+
 // SaveBalance implements Repository.SaveBalance
 func (r *PrometheusRepository) SaveBalance(ctx context.Context, balance *models.Balance) error {
 	// 메모리에 밸런스 정보 저장
@@ -136,10 +195,13 @@ func (r *PrometheusRepository) UpdateBalance(ctx context.Context, balance *model
 
 	poolParticipatedCount := float64(balance.PoolParticipatedCount)
 
+	r.trackSeries(r.addressSeries, balance.Address, balance.Label, balance.Network)
+
 	// 기본 메트릭 업데이트 (validator_index 유무와 관계없이 항상 표시)
 	r.client.UpdateBasicMetrics(
 		balance.Address,
 		balance.Label,
+		balance.Network,
 		balanceValue,
 		stakedAmount,
 		reward,
@@ -148,11 +210,12 @@ func (r *PrometheusRepository) UpdateBalance(ctx context.Context, balance *model
 		lastRewardTime,
 	)
 
-	// validator_index가 있는 경우에만 특정 메트릭 업데이트
-	if balance.ValidatorIndex != "" && strings.TrimSpace(balance.ValidatorIndex) != "" {
+	// 인덱스가 확인된 validator가 있는 경우에만 특정 메트릭 업데이트 (여러 validator의 합계)
+	if len(balance.KnownValidators()) > 0 {
 		r.client.UpdateValidatorRelatedMetrics(
 			balance.Address,
 			balance.Label,
+			balance.Network,
 			stakingBalance,
 			dailyReward,
 			latestIncome,
@@ -160,22 +223,68 @@ func (r *PrometheusRepository) UpdateBalance(ctx context.Context, balance *model
 		)
 	}
 
+	// 소스별 데이터 신선도 업데이트
+	for source, status := range balance.Sources {
+		r.client.UpdateDataStaleness(balance.Address, balance.Label, balance.Network, source, status.Stale)
+	}
+
 	return nil
 }
 
-// DeleteBalance implements Repository.DeleteBalance
+// DeleteBalance implements Repository.DeleteBalance. It forgets the balance
+// and deletes every series exported for the address and for its validators
+// that no other address is backed by.
 func (r *PrometheusRepository) DeleteBalance(ctx context.Context, address string) error {
-	// Prometheus doesn't support deletion of metrics
+	r.balancesMutex.Lock()
+	balance, exists := r.balances[address]
+	delete(r.balances, address)
+
+	// 다른 주소에도 설정된 검증자의 시리즈는 유지
+	var orphaned []string
+	if exists {
+		for _, validator := range balance.KnownValidators() {
+			if !r.validatorInUse(balance.Network, validator.Index) {
+				orphaned = append(orphaned, validator.Index)
+			}
+		}
+	}
+	r.balancesMutex.Unlock()
+
+	for _, labels := range r.untrackSeries(r.addressSeries, address) {
+		r.client.DeleteAddressMetrics(address, labels.label, labels.network, sources)
+	}
+	for _, validatorIdx := range orphaned {
+		if err := r.DeleteValidatorReward(ctx, balance.Network, validatorIdx); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// validatorInUse reports whether a stored balance on network is backed by the
+// validator; callers must hold balancesMutex
+func (r *PrometheusRepository) validatorInUse(network, validatorIdx string) bool {
+	for _, balance := range r.balances {
+		if balance.Network != network {
+			continue
+		}
+		for _, validator := range balance.KnownValidators() {
+			if validator.Index == validatorIdx {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // SaveValidatorReward implements Repository.SaveValidatorReward
 func (r *PrometheusRepository) SaveValidatorReward(ctx context.Context, reward *models.ValidatorReward) error {
 	return r.UpdateValidatorReward(ctx, reward)
 }
 
 // GetValidatorReward implements Repository.GetValidatorReward
-func (r *PrometheusRepository) GetValidatorReward(ctx context.Context, validatorIdx string) (*models.ValidatorReward, error) {
+func (r *PrometheusRepository) GetValidatorReward(ctx context.Context, network, validatorIdx string) (*models.ValidatorReward, error) {
 	// Prometheus is not designed for data retrieval
 	return nil, nil
 }
@@ -225,18 +334,21 @@ func (r *PrometheusRepository) UpdateValidatorReward(ctx context.Context, reward
 		}
 	}
 
-	// Parse validator balance
+	// Parse validator balance (이미 네트워크의 Gwei 단위로 DILL 환산된 값)
 	validatorBalance := 0.0
 	if reward.Balance != "" {
 		if val, err := strconv.ParseFloat(reward.Balance, 64); err == nil {
-			validatorBalance = val / 1e9 // Convert to DILL (assuming balance is in Gwei)
+			validatorBalance = val
 		}
 	}
 
+	r.trackSeries(r.validatorSeries, reward.ValidatorIdx, reward.UserLabel, reward.Network)
+
 	// For now, use validator index as label
 	r.client.UpdateValidatorMetrics(
 		reward.ValidatorIdx,
 		reward.UserLabel,
+		reward.Network,
 		reward.LastReward,
 		validatorBalance,
 		isActive,
@@ -245,12 +357,77 @@ func (r *PrometheusRepository) UpdateValidatorReward(ctx context.Context, reward
 		reward.Status,
 	)
 
+	// 상태 변경을 본 적이 없으면 처음 관측한 시각을 내보냄
+	r.eventsMutex.RLock()
+	event, exists := r.latestEvents[eventKey{reward.ValidatorIdx, reward.Network}]
+	r.eventsMutex.RUnlock()
+	if exists {
+		r.client.UpdateValidatorStatusSince(reward.ValidatorIdx, reward.UserLabel, reward.Network, event.Time)
+	}
+
+	return nil
+}
+
+// DeleteValidatorReward implements Repository.DeleteValidatorReward. It deletes
+// every series exported for the validator on the network.
+func (r *PrometheusRepository) DeleteValidatorReward(ctx context.Context, network, validatorIdx string) error {
+	stale := r.pruneTracked(r.validatorSeries, func(key string, labels seriesLabels) bool {
+		return key != validatorIdx || labels.network != network
+	})
+	for _, labels := range stale[validatorIdx] {
+		r.client.DeleteValidatorMetrics(validatorIdx, labels.label, labels.network)
+	}
+	return nil
+}
+
+// SaveValidatorEvent implements Repository.SaveValidatorEvent. Only the
+// latest event of every validator is kept in memory; its time is exported as
+// the time since which the validator has its current status.
+func (r *PrometheusRepository) SaveValidatorEvent(ctx context.Context, event *models.ValidatorEvent) error {
+	r.eventsMutex.Lock()
+	defer r.eventsMutex.Unlock()
+
+	key := eventKey{event.ValidatorIdx, event.Network}
+	if latest, exists := r.latestEvents[key]; !exists || !event.Time.Before(latest.Time) {
+		r.latestEvents[key] = event
+	}
+	return nil
+}
+
+// ListValidatorEvents implements Repository.ListValidatorEvents. Without
+// persistent storage only the latest event of every validator is available.
+func (r *PrometheusRepository) ListValidatorEvents(ctx context.Context, query models.EventQuery) ([]*models.ValidatorEvent, error) {
+	r.eventsMutex.RLock()
+	defer r.eventsMutex.RUnlock()
+
+	var events []*models.ValidatorEvent
+	for _, event := range r.latestEvents {
+		if query.Matches(event) {
+			events = append(events, event)
+		}
+	}
+	models.SortEvents(events)
+	return events, nil
+}
+
+// LatestValidatorEvents implements Repository.LatestValidatorEvents
+func (r *PrometheusRepository) LatestValidatorEvents(ctx context.Context) ([]*models.ValidatorEvent, error) {
+	return r.ListValidatorEvents(ctx, models.EventQuery{})
+}
+
+// SaveSnapshots implements Repository.SaveSnapshots. Prometheus keeps no
+// history of its own, so snapshots are dropped.
+func (r *PrometheusRepository) SaveSnapshots(ctx context.Context, snapshots []*models.Snapshot) error {
 	return nil
 }
 
-// DeleteValidatorReward implements Repository.DeleteValidatorReward
-func (r *PrometheusRepository) DeleteValidatorReward(ctx context.Context, validatorIdx string) error {
-	// Prometheus doesn't support deletion of metrics
+// QueryHistory implements Repository.QueryHistory
+func (r *PrometheusRepository) QueryHistory(ctx context.Context, query models.HistoryQuery) ([]*models.Snapshot, error) {
+	return nil, ErrHistoryUnavailable
+}
+
+// CompactHistory implements Repository.CompactHistory
+func (r *PrometheusRepository) CompactHistory(ctx context.Context, policy models.RetentionPolicy, now time.Time) error {
 	return nil
 }
 
@@ -265,70 +442,260 @@ func (r *PrometheusRepository) RecordValidatorRewardMetric(reward *models.Valida
 }
 
 // RecordAPIMetric implements Repository.RecordAPIMetric
-func (r *PrometheusRepository) RecordAPIMetric(endpoint string, duration float64, status int) error {
-	r.client.RecordAPIMetrics(endpoint, "GET", status, duration)
+func (r *PrometheusRepository) RecordAPIMetric(network, endpoint, method string, duration float64, status int) error {
+	r.client.RecordAPIMetrics(network, endpoint, method, status, duration)
+	return nil
+}
+
+// RecordAPIError implements Repository.RecordAPIError
+func (r *PrometheusRepository) RecordAPIError(network, endpoint, method, errorType string) error {
+	r.client.RecordAPIError(network, endpoint, method, errorType)
+	return nil
+}
+
+// RecordUpstreamRetry implements Repository.RecordUpstreamRetry
+func (r *PrometheusRepository) RecordUpstreamRetry(network, endpoint string) error {
+	r.client.RecordUpstreamRetry(network, endpoint)
 	return nil
 }
 
-// UpdateSummaryMetrics updates the summary metrics with aggregated data
+// RecordUpstreamFailure implements Repository.RecordUpstreamFailure
+func (r *PrometheusRepository) RecordUpstreamFailure(network, endpoint string) error {
+	r.client.RecordUpstreamFailure(network, endpoint)
+	return nil
+}
+
+// RecordScrape implements Repository.RecordScrape
+func (r *PrometheusRepository) RecordScrape(address, label, network, source string, success bool, duration float64) error {
+	r.trackSeries(r.addressSeries, address, label, network)
+	r.client.RecordScrape(address, label, network, source, success, duration, time.Now())
+	return nil
+}
+
+// RecordNextRun implements Repository.RecordNextRun
+func (r *PrometheusRepository) RecordNextRun(address, label, network string, next time.Time) error {
+	r.trackSeries(r.addressSeries, address, label, network)
+	r.client.UpdateNextRun(address, label, network, next)
+	return nil
+}
+
+// RecordRateLimitWait implements Repository.RecordRateLimitWait
+func (r *PrometheusRepository) RecordRateLimitWait(host string, wait float64) error {
+	r.client.RecordRateLimitWait(host, wait)
+	return nil
+}
+
+// RecordQueueDepth implements Repository.RecordQueueDepth
+func (r *PrometheusRepository) RecordQueueDepth(depth int) error {
+	r.client.UpdateWorkerQueueDepth(depth)
+	return nil
+}
+
+// RecordCycleDuration implements Repository.RecordCycleDuration
+func (r *PrometheusRepository) RecordCycleDuration(duration float64) error {
+	r.client.RecordCycleDuration(duration)
+	return nil
+}
+
+// RecordCycleSkipped implements Repository.RecordCycleSkipped
+func (r *PrometheusRepository) RecordCycleSkipped() error {
+	r.client.RecordCycleSkipped()
+	return nil
+}
+
+// RecordValidatorDiscovered implements Repository.RecordValidatorDiscovered
+func (r *PrometheusRepository) RecordValidatorDiscovered(address, label, network string) error {
+	r.trackSeries(r.addressSeries, address, label, network)
+	r.client.RecordValidatorDiscovered(address, label, network)
+	return nil
+}
+
+// RecordConfigReload implements Repository.RecordConfigReload
+func (r *PrometheusRepository) RecordConfigReload(success bool, at time.Time) error {
+	r.client.RecordConfigReload(success, at)
+	return nil
+}
+
+// UpdateAlertThresholds implements Repository.UpdateAlertThresholds
+func (r *PrometheusRepository) UpdateAlertThresholds(thresholds map[string]float64) error {
+	r.client.SetAlertThresholds(thresholds)
+	return nil
+}
+
+// PruneSeries implements Repository.PruneSeries. Series of addresses that are
+// not given, or were exported with another label or network, are deleted, and
+// so are the series of validators not backing a given address with its label
+// and network, e.g. after a label change or after a validator moved to
+// another address.
+func (r *PrometheusRepository) PruneSeries(ctx context.Context, addresses []models.Address) (int, error) {
+	keepAddresses := make(map[string]seriesLabels, len(addresses))
+	keepValidators := make(map[string]map[seriesLabels]bool)
+	r.balancesMutex.RLock()
+	for _, addr := range addresses {
+		labels := seriesLabels{addr.Label, addr.Network}
+		keepAddresses[addr.Address] = labels
+
+		balance, exists := r.balances[addr.Address]
+		if !exists {
+			continue
+		}
+		for _, validator := range balance.KnownValidators() {
+			if keepValidators[validator.Index] == nil {
+				keepValidators[validator.Index] = make(map[seriesLabels]bool)
+			}
+			keepValidators[validator.Index][labels] = true
+		}
+	}
+	r.balancesMutex.RUnlock()
+
+	staleAddresses := r.pruneTracked(r.addressSeries, func(key string, labels seriesLabels) bool {
+		keep, exists := keepAddresses[key]
+		return exists && keep == labels
+	})
+	staleValidators := r.pruneTracked(r.validatorSeries, func(key string, labels seriesLabels) bool {
+		return keepValidators[key][labels]
+	})
+
+	for key, labels := range staleAddresses {
+		for _, l := range labels {
+			r.client.DeleteAddressMetrics(key, l.label, l.network, sources)
+		}
+	}
+	for key, labels := range staleValidators {
+		for _, l := range labels {
+			r.client.DeleteValidatorMetrics(key, l.label, l.network)
+		}
+	}
+
+	pruned := 0
+	for _, labels := range staleAddresses {
+		pruned += len(labels)
+	}
+	for _, labels := range staleValidators {
+		pruned += len(labels)
+	}
+	return pruned, nil
+}
+
+// pruneTracked forgets and returns the tracked labels for which keep is false
+func (r *PrometheusRepository) pruneTracked(series map[string]map[seriesLabels]bool, keep func(key string, labels seriesLabels) bool) map[string][]seriesLabels {
+	r.seriesMutex.Lock()
+	defer r.seriesMutex.Unlock()
+
+	stale := make(map[string][]seriesLabels)
+	for key, tracked := range series {
+		for labels := range tracked {
+			if keep(key, labels) {
+				continue
+			}
+			stale[key] = append(stale[key], labels)
+			delete(tracked, labels)
+		}
+		if len(tracked) == 0 {
+			delete(series, key)
+		}
+	}
+	return stale
+}
+
+// networkSummary holds the aggregated values of one network
+type networkSummary struct {
+	addressCount         int
+	validatorCount       int
+	activeValidatorCount int
+	totalBalance         float64
+	totalReward          float64
+	totalStakedAmount    float64
+}
+
+// UpdateSummaryMetrics updates the summary metrics with aggregated data per
+// network. A validator shared by several addresses is counted once.
 func (r *PrometheusRepository) UpdateSummaryMetrics(ctx context.Context, balances []*models.Balance) error {
-	addressCount := len(balances)
-	var totalBalance, totalReward, totalStakedAmount float64
-	validatorCount := 0
-	activeValidatorCount := 0
+	summaries := make(map[string]*networkSummary)
 
-	// 상태별 밸리데이터 수를 추적하는 맵
-	statusCounts := make(map[string]int)
+	// 네트워크, 상태별 밸리데이터 수를 추적하는 맵
+	statusCounts := make(map[string]map[string]int)
+	// 여러 주소에 설정된 밸리데이터를 한 번만 세기 위한 네트워크/인덱스 집합
+	counted := make(map[string]bool)
 
 	for _, balance := range balances {
+		summary, exists := summaries[balance.Network]
+		if !exists {
+			summary = &networkSummary{}
+			summaries[balance.Network] = summary
+			statusCounts[balance.Network] = make(map[string]int)
+		}
+		summary.addressCount++
+
 		// Parse balance
 		if strings.TrimSpace(balance.Balance) != "" {
 			if val, err := strconv.ParseFloat(strings.TrimSuffix(balance.Balance, " DILL"), 64); err == nil {
-				totalBalance += val
+				summary.totalBalance += val
 			}
 		}
 
 		// Parse reward
 		if strings.TrimSpace(balance.Reward) != "" {
 			if val, err := strconv.ParseFloat(balance.Reward, 64); err == nil {
-				totalReward += val
+				summary.totalReward += val
 			}
 		}
 
 		// Parse staked amount
 		if strings.TrimSpace(balance.StakedAmount) != "" {
 			if val, err := strconv.ParseFloat(balance.StakedAmount, 64); err == nil {
-				totalStakedAmount += val
+				summary.totalStakedAmount += val
 			}
 		}
 
 		// Count validators
-		if balance.ValidatorIndex != "" && strings.TrimSpace(balance.ValidatorIndex) != "" {
-			validatorCount++
+		for _, validator := range balance.KnownValidators() {
+			key := rewardKey(balance.Network, validator.Index)
+			if counted[key] {
+				continue
+			}
+			counted[key] = true
+			summary.validatorCount++
 
 			// Count active validators
-			if strings.Contains(strings.ToLower(balance.Status), "active") {
-				activeValidatorCount++
+			if strings.Contains(strings.ToLower(validator.Status), "active") {
+				summary.activeValidatorCount++
 			}
 
 			// 상태별 카운트 증가
-			status := strings.TrimSpace(balance.Status)
+			status := strings.TrimSpace(validator.Status)
 			if status == "" {
 				status = "unknown"
 			}
-			statusCounts[status]++
+			statusCounts[balance.Network][status]++
 		}
 	}
 
 	// Update summary metrics
-	r.client.UpdateSummaryMetrics(
-		addressCount,
-		validatorCount,
-		activeValidatorCount,
-		totalBalance,
-		totalReward,
-		totalStakedAmount,
-	)
+	for network, summary := range summaries {
+		r.client.UpdateSummaryMetrics(
+			network,
+			summary.addressCount,
+			summary.validatorCount,
+			summary.activeValidatorCount,
+			summary.totalBalance,
+			summary.totalReward,
+			summary.totalStakedAmount,
+		)
+	}
+
+	// 주소가 모두 제거된 네트워크의 요약 시리즈 삭제
+	r.seriesMutex.Lock()
+	for network := range r.summaryNetworks {
+		if _, exists := summaries[network]; !exists {
+			r.client.DeleteSummaryMetrics(network)
+			delete(r.summaryNetworks, network)
+		}
+	}
+	for network := range summaries {
+		r.summaryNetworks[network] = true
+	}
+	r.seriesMutex.Unlock()
 
 	// 상태별 밸리데이터 수 업데이트
 	r.client.UpdateValidatorStatusMetrics(statusCounts)
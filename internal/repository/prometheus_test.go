@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"dill-monitor/internal/models"
+	"dill-monitor/pkg/metrics"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// metrics are registered globally, so every test shares one client
+var testMetrics = metrics.NewPrometheusClient()
+
+// gaugeValue returns the value of the series of a metric with the given
+// labels, and whether it is exported
+func gaugeValue(t *testing.T, name string, labels map[string]string) (float64, bool) {
+	t.Helper()
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, metric := range family.GetMetric() {
+			for _, pair := range metric.GetLabel() {
+				if labels[pair.GetName()] != pair.GetValue() {
+					continue metrics
+				}
+			}
+			return metric.GetGauge().GetValue(), true
+		}
+	}
+	return 0, false
+}
+
+func TestPrometheusStatusSince(t *testing.T) {
+	observed := time.Date(2025, 5, 19, 12, 0, 0, 0, time.UTC)
+	changed := observed.Add(6 * time.Hour)
+
+	tests := []struct {
+		name   string
+		events []*models.ValidatorEvent
+		want   time.Time
+	}{
+		{"no event", nil, time.Time{}},
+		{"first observation", []*models.ValidatorEvent{{To: "active_ongoing", Time: observed}}, observed},
+		{
+			name: "status change",
+			events: []*models.ValidatorEvent{
+				{To: "active_ongoing", Time: observed},
+				{From: "active_ongoing", To: "active_exiting", Time: changed},
+			},
+			want: changed,
+		},
+		{
+			// 늦게 도착한 이전 이벤트는 최신 이벤트를 덮어쓰지 않음
+			name: "older event",
+			events: []*models.ValidatorEvent{
+				{From: "active_ongoing", To: "active_exiting", Time: changed},
+				{To: "active_ongoing", Time: observed},
+			},
+			want: changed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewPrometheusRepository(testMetrics)
+			ctx := context.Background()
+			// 하위 테스트마다 다른 네트워크로 시리즈를 분리
+			network := tt.name
+
+			for _, event := range tt.events {
+				event.ValidatorIdx, event.Network = "17021", network
+				if err := repo.SaveValidatorEvent(ctx, event); err != nil {
+					t.Fatalf("SaveValidatorEvent: %v", err)
+				}
+			}
+			err := repo.UpdateValidatorReward(ctx, &models.ValidatorReward{ValidatorIdx: "17021", UserLabel: "a", Network: network, Status: "active_ongoing"})
+			if err != nil {
+				t.Fatalf("UpdateValidatorReward: %v", err)
+			}
+
+			got, exported := gaugeValue(t, "validator_status_since_timestamp_seconds", map[string]string{"validator_idx": "17021", "label": "a", "network": network})
+			if tt.want.IsZero() {
+				if exported {
+					t.Errorf("status since = %v, want no series", got)
+				}
+				return
+			}
+			if !exported || got != float64(tt.want.Unix()) {
+				t.Errorf("status since = %v (exported %v), want %d", got, exported, tt.want.Unix())
+			}
+		})
+	}
+}
+
+func TestPrometheusPruneSeries(t *testing.T) {
+	repo := NewPrometheusRepository(testMetrics)
+	ctx := context.Background()
+	// 다른 테스트와 시리즈가 섞이지 않도록 전용 네트워크 사용
+	const network = "prune"
+
+	balances := []*models.Balance{
+		{Address: "0xa", Label: "a", Network: network, Validators: []models.ValidatorBalance{{Index: "1"}}},
+		{Address: "0xb", Label: "b", Network: network, Validators: []models.ValidatorBalance{{Index: "2"}}},
+		{Address: "0xc", Label: "c", Network: network, Validators: []models.ValidatorBalance{{Index: "3"}}},
+	}
+	for _, balance := range balances {
+		if err := repo.SaveBalance(ctx, balance); err != nil {
+			t.Fatalf("SaveBalance: %v", err)
+		}
+		validator := balance.Validators[0]
+		err := repo.UpdateValidatorReward(ctx, &models.ValidatorReward{ValidatorIdx: validator.Index, UserLabel: balance.Label, Network: network})
+		if err != nil {
+			t.Fatalf("UpdateValidatorReward: %v", err)
+		}
+	}
+
+	// a는 그대로, b는 라벨이 바뀌고 c는 설정에서 삭제됨
+	addresses := []models.Address{
+		{Address: "0xa", Label: "a", Network: network},
+		{Address: "0xb", Label: "renamed", Network: network},
+	}
+	pruned, err := repo.PruneSeries(ctx, addresses)
+	if err != nil {
+		t.Fatalf("PruneSeries: %v", err)
+	}
+	// b와 c의 주소, 검증자 시리즈
+	if pruned != 4 {
+		t.Errorf("pruned %d label sets, want 4", pruned)
+	}
+
+	tests := []struct {
+		metric   string
+		labels   map[string]string
+		exported bool
+	}{
+		{"account_balance", map[string]string{"address": "0xa", "label": "a", "network": network}, true},
+		{"account_balance", map[string]string{"address": "0xb", "label": "b", "network": network}, false},
+		{"account_balance", map[string]string{"address": "0xc", "label": "c", "network": network}, false},
+		{"validator_balance", map[string]string{"validator_idx": "1", "label": "a", "network": network}, true},
+		{"validator_balance", map[string]string{"validator_idx": "2", "label": "b", "network": network}, false},
+		{"validator_balance", map[string]string{"validator_idx": "3", "label": "c", "network": network}, false},
+	}
+	for _, tt := range tests {
+		if _, exported := gaugeValue(t, tt.metric, tt.labels); exported != tt.exported {
+			t.Errorf("%s%v exported = %v, want %v", tt.metric, tt.labels, exported, tt.exported)
+		}
+	}
+
+	// 이미 정리된 시리즈는 다시 세지 않음
+	if pruned, err := repo.PruneSeries(ctx, addresses); err != nil || pruned != 0 {
+		t.Errorf("second PruneSeries = %d, %v, want nothing pruned", pruned, err)
+	}
+}
@@ -0,0 +1,350 @@
+package scheduler
+
+import (
+	"context"
+	"dill-monitor/internal/chainclock"
+	"dill-monitor/internal/models"
+	"fmt"
+	"log"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+// IntervalEpoch is the interval value that aligns an address with epoch boundaries
+const IntervalEpoch = "epoch"
+
+// Overlap policies for addresses that become due while a cycle is still running
+const (
+	// OverlapQueue processes them as soon as the running cycle finishes
+	OverlapQueue = "queue"
+	// OverlapSkip drops them until their next scheduled run
+	OverlapSkip = "skip"
+)
+
+// Recorder receives the next planned run of every address
+type Recorder interface {
+	RecordNextRun(addr models.Address, next time.Time) error
+}
+
+// CycleRecorder receives the duration of every cycle and the cycles skipped
+// because the previous one was still running
+type CycleRecorder interface {
+	RecordCycleDuration(duration float64) error
+	RecordCycleSkipped() error
+}
+
+// ClockSource returns the chain clock of the network an address is monitored on
+type ClockSource interface {
+	ChainClock(addr models.Address) (*chainclock.Clock, error)
+}
+
+// Options configures a scheduler
+type Options struct {
+	// Interval is the default polling interval
+	Interval time.Duration
+	// Jitter is the maximum random delay added to every scheduled run
+	Jitter time.Duration
+	// EpochAligned schedules addresses without an interval override on epoch boundaries
+	EpochAligned bool
+	// EpochOffset is the delay after an epoch boundary before epoch-aligned addresses run
+	EpochOffset time.Duration
+	// Clocks is required when EpochAligned is set or an address uses the "epoch" interval
+	Clocks ClockSource
+	// CycleTimeout bounds a cycle; zero derives it from the shortest schedule of the due addresses
+	CycleTimeout time.Duration
+	// Overlap is OverlapQueue (default) or OverlapSkip
+	Overlap string
+	// Recorder receives the next run of every address, it may be nil
+	Recorder Recorder
+	// Cycles receives cycle durations and skipped cycles, it may be nil
+	Cycles CycleRecorder
+}
+
+// RunFunc processes the addresses that are due
+type RunFunc func(ctx context.Context, due []models.Address)
+
+// entry is the schedule of a single address
+type entry struct {
+	addr     models.Address
+	interval time.Duration
+	// clock is set for addresses aligned with epoch boundaries
+	clock *chainclock.Clock
+	next  time.Time
+}
+
+// sameSchedule reports whether two entries are scheduled the same way
+func (e *entry) sameSchedule(other *entry) bool {
+	return e.interval == other.interval && e.clock == other.clock
+}
+
+// period returns the time between two runs of the entry
+func (e *entry) period() time.Duration {
+	if e.clock != nil {
+		return e.clock.EpochDuration()
+	}
+	return e.interval
+}
+
+// nextRun returns the next run of the entry after now
+func (e *entry) nextRun(now time.Time, offset, jitter time.Duration) time.Time {
+	if e.clock != nil {
+		return e.clock.NextEpochStart(now).Add(offset + jitter)
+	}
+	return now.Add(e.interval + jitter)
+}
+
+// Scheduler runs every address on its own interval, or shortly after every
+// epoch boundary of its network, replacing a global ticker. Addresses that
+// become due at the same time are processed together.
+type Scheduler struct {
+	mu      sync.Mutex
+	opts    Options
+	entries map[string]*entry
+
+	// wake interrupts the wait for the next run when the schedule changes
+	wake chan struct{}
+}
+
+// New creates a scheduler
+func New(opts Options) *Scheduler {
+	return &Scheduler{
+		opts:    opts,
+		entries: make(map[string]*entry),
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// SetAddresses replaces the scheduled addresses. Addresses that were already
+// scheduled with the same interval keep their next run; new ones are due immediately.
+func (s *Scheduler) SetAddresses(addresses []models.Address) error {
+	entries := make(map[string]*entry, len(addresses))
+	for _, addr := range addresses {
+		e, err := s.newEntry(addr)
+		if err != nil {
+			return err
+		}
+		entries[addr.Address] = e
+	}
+
+	s.mu.Lock()
+	now := time.Now()
+	for key, e := range entries {
+		if old, exists := s.entries[key]; exists && old.sameSchedule(e) {
+			e.next = old.next
+		} else {
+			e.next = now
+		}
+		s.record(e)
+	}
+	s.entries = entries
+	s.mu.Unlock()
+
+	s.notify()
+	return nil
+}
+
+// Validate checks that the addresses can be scheduled without changing the schedule
+func (s *Scheduler) Validate(addresses []models.Address) error {
+	for _, addr := range addresses {
+		if _, err := s.newEntry(addr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run waits for addresses to become due and processes them until ctx is done.
+// Only one cycle runs at a time; addresses that become due while a cycle is
+// running are queued or skipped according to the overlap policy. Run returns
+// once the running cycle, if any, has finished.
+func (s *Scheduler) Run(ctx context.Context, run RunFunc) {
+	var finished chan struct{}
+
+	for {
+		// 큐 정책에서는 실행 중인 사이클이 끝날 때까지 새 사이클을 시작하지 않음
+		var timerC <-chan time.Time
+		var timer *time.Timer
+		if finished == nil || s.opts.Overlap == OverlapSkip {
+			timer = time.NewTimer(s.untilNext())
+			timerC = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			stopTimer(timer)
+			if finished != nil {
+				<-finished
+			}
+			return
+		case <-s.wake:
+			stopTimer(timer)
+			continue
+		case <-finished:
+			stopTimer(timer)
+			finished = nil
+			continue
+		case <-timerC:
+		}
+
+		due, timeout := s.takeDue(time.Now())
+		if len(due) == 0 {
+			continue
+		}
+
+		if finished != nil {
+			log.Printf("Skipping %d addresses due while the previous cycle is still running", len(due))
+			s.recordSkipped()
+			continue
+		}
+
+		finished = make(chan struct{})
+		go s.cycle(ctx, due, timeout, run, finished)
+	}
+}
+
+// cycle processes the due addresses within the cycle deadline and closes finished
+func (s *Scheduler) cycle(ctx context.Context, due []models.Address, timeout time.Duration, run RunFunc, finished chan struct{}) {
+	defer close(finished)
+
+	cycleCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	log.Printf("Processing %d scheduled addresses (deadline %s)...", len(due), timeout)
+	start := time.Now()
+	run(cycleCtx, due)
+	duration := time.Since(start)
+
+	if cycleCtx.Err() == context.DeadlineExceeded {
+		log.Printf("Cycle exceeded its deadline of %s", timeout)
+	}
+	s.recordCycle(duration)
+}
+
+// untilNext returns the time until the earliest scheduled run
+func (s *Scheduler) untilNext() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 등록된 주소가 없으면 기본 간격만큼 대기
+	wait := s.opts.Interval
+	now := time.Now()
+	for _, e := range s.entries {
+		if d := e.next.Sub(now); d < wait {
+			wait = d
+		}
+	}
+	if wait < 0 {
+		wait = 0
+	}
+	return wait
+}
+
+// takeDue returns the addresses due at now together with the deadline of
+// their cycle, and schedules their next run
+func (s *Scheduler) takeDue(now time.Time) ([]models.Address, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var due []models.Address
+	timeout := s.opts.CycleTimeout
+	for _, e := range s.entries {
+		if e.next.After(now) {
+			continue
+		}
+		due = append(due, e.addr)
+		e.next = e.nextRun(now, s.opts.EpochOffset, s.randomJitter())
+		s.record(e)
+
+		// 사이클은 가장 짧은 주기 안에 끝나야 다음 실행과 겹치지 않음
+		if s.opts.CycleTimeout <= 0 && (timeout <= 0 || e.period() < timeout) {
+			timeout = e.period()
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool { return due[i].Address < due[j].Address })
+	return due, timeout
+}
+
+// newEntry creates the schedule of an address from its interval override
+func (s *Scheduler) newEntry(addr models.Address) (*entry, error) {
+	e := &entry{addr: addr, interval: s.opts.Interval}
+
+	switch {
+	case addr.Interval == IntervalEpoch || (addr.Interval == "" && s.opts.EpochAligned):
+		if s.opts.Clocks == nil {
+			return nil, fmt.Errorf("epoch-aligned polling of address %s requires a chain clock", addr.Address)
+		}
+		clock, err := s.opts.Clocks.ChainClock(addr)
+		if err != nil {
+			return nil, fmt.Errorf("epoch-aligned polling of address %s: %v", addr.Address, err)
+		}
+		e.clock = clock
+
+	case addr.Interval != "":
+		interval, err := time.ParseDuration(addr.Interval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid interval %q for address %s: %v", addr.Interval, addr.Address, err)
+		}
+		if interval <= 0 {
+			return nil, fmt.Errorf("interval for address %s must be positive", addr.Address)
+		}
+		e.interval = interval
+	}
+
+	return e, nil
+}
+
+// randomJitter returns a random delay in [0, jitter)
+func (s *Scheduler) randomJitter() time.Duration {
+	if s.opts.Jitter <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(s.opts.Jitter)))
+}
+
+// record reports the next run of an entry to the recorder, if any
+func (s *Scheduler) record(e *entry) {
+	if s.opts.Recorder == nil {
+		return
+	}
+	if err := s.opts.Recorder.RecordNextRun(e.addr, e.next); err != nil {
+		log.Printf("Error recording next run for %s: %v", e.addr.Address, err)
+	}
+}
+
+// recordCycle reports the duration of a cycle to the cycle recorder, if any
+func (s *Scheduler) recordCycle(duration time.Duration) {
+	if s.opts.Cycles == nil {
+		return
+	}
+	if err := s.opts.Cycles.RecordCycleDuration(duration.Seconds()); err != nil {
+		log.Printf("Error recording cycle duration: %v", err)
+	}
+}
+
+// recordSkipped reports a skipped cycle to the cycle recorder, if any
+func (s *Scheduler) recordSkipped() {
+	if s.opts.Cycles == nil {
+		return
+	}
+	if err := s.opts.Cycles.RecordCycleSkipped(); err != nil {
+		log.Printf("Error recording skipped cycle: %v", err)
+	}
+}
+
+// stopTimer stops a timer that may be nil
+func stopTimer(timer *time.Timer) {
+	if timer != nil {
+		timer.Stop()
+	}
+}
+
+// notify wakes up Run so that it recomputes the next run
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
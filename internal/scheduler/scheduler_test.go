@@ -0,0 +1,287 @@
+package scheduler
+
+import (
+	"context"
+	"dill-monitor/internal/chainclock"
+	"dill-monitor/internal/models"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testClocks returns the same chain clock for every address
+type testClocks struct {
+	clock *chainclock.Clock
+}
+
+func (c testClocks) ChainClock(addr models.Address) (*chainclock.Clock, error) {
+	if c.clock == nil {
+		return nil, fmt.Errorf("unknown network %q", addr.Network)
+	}
+	return c.clock, nil
+}
+
+// newTestClock returns a clock with 12 second slots and 32 slot epochs
+func newTestClock(t *testing.T, genesis time.Time) *chainclock.Clock {
+	t.Helper()
+
+	clock, err := chainclock.New(genesis, 12, 32)
+	if err != nil {
+		t.Fatalf("chainclock.New: %v", err)
+	}
+	return clock
+}
+
+func TestValidate(t *testing.T) {
+	clocks := testClocks{clock: newTestClock(t, time.Unix(1700000000, 0))}
+
+	tests := []struct {
+		name string
+		opts Options
+		addr models.Address
+		err  bool
+	}{
+		{"default interval", Options{Interval: time.Minute}, models.Address{Address: "0x1"}, false},
+		{"interval override", Options{Interval: time.Minute}, models.Address{Address: "0x1", Interval: "30s"}, false},
+		{"invalid interval", Options{Interval: time.Minute}, models.Address{Address: "0x1", Interval: "soon"}, true},
+		{"zero interval", Options{Interval: time.Minute}, models.Address{Address: "0x1", Interval: "0s"}, true},
+		{"negative interval", Options{Interval: time.Minute}, models.Address{Address: "0x1", Interval: "-1m"}, true},
+		{"epoch interval", Options{Interval: time.Minute, Clocks: clocks}, models.Address{Address: "0x1", Interval: IntervalEpoch}, false},
+		{"epoch interval without clocks", Options{Interval: time.Minute}, models.Address{Address: "0x1", Interval: IntervalEpoch}, true},
+		{"epoch aligned without clock", Options{Interval: time.Minute, EpochAligned: true, Clocks: testClocks{}}, models.Address{Address: "0x1"}, true},
+		{"override of epoch alignment", Options{Interval: time.Minute, EpochAligned: true}, models.Address{Address: "0x1", Interval: "5m"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := New(tt.opts).Validate([]models.Address{tt.addr})
+			if (err != nil) != tt.err {
+				t.Errorf("Validate error = %v, want error %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestSetAddresses(t *testing.T) {
+	s := New(Options{Interval: time.Minute})
+	if err := s.SetAddresses([]models.Address{
+		{Address: "0x1"},
+		{Address: "0x2", Interval: "10s"},
+	}); err != nil {
+		t.Fatalf("SetAddresses: %v", err)
+	}
+
+	// 처음 추가된 주소는 즉시 실행
+	due, _ := s.takeDue(time.Now())
+	if len(due) != 2 {
+		t.Fatalf("due = %+v, want both addresses", due)
+	}
+	next := map[string]time.Time{"0x1": s.entries["0x1"].next, "0x2": s.entries["0x2"].next}
+
+	// 주기가 같은 주소는 다음 실행 시각을 유지하고 주기가 바뀐 주소와 새 주소는 즉시 실행
+	if err := s.SetAddresses([]models.Address{
+		{Address: "0x1", Label: "renamed"},
+		{Address: "0x2", Interval: "20s"},
+		{Address: "0x3"},
+	}); err != nil {
+		t.Fatalf("SetAddresses: %v", err)
+	}
+	if got := s.entries["0x1"].next; !got.Equal(next["0x1"]) {
+		t.Errorf("next run of 0x1 = %s, want %s kept", got, next["0x1"])
+	}
+	if got := s.entries["0x2"].next; !got.Before(next["0x2"]) {
+		t.Errorf("next run of 0x2 = %s, want it due after the interval changed", got)
+	}
+
+	due, _ = s.takeDue(time.Now())
+	var got []string
+	for _, addr := range due {
+		got = append(got, addr.Address)
+	}
+	if want := []string{"0x2", "0x3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("due = %v, want %v", got, want)
+	}
+
+	// 실패한 변경은 현재 일정을 유지
+	if err := s.SetAddresses([]models.Address{{Address: "0x4", Interval: "soon"}}); err == nil {
+		t.Fatal("SetAddresses succeeded, want the invalid interval rejected")
+	}
+	if len(s.entries) != 3 {
+		t.Errorf("entries = %d, want the current schedule kept", len(s.entries))
+	}
+}
+
+func TestTakeDue(t *testing.T) {
+	// 새 주소는 현재 시각에 실행되므로 기준 시각은 현재 이후여야 함
+	epoch := 384 * time.Second
+	genesis := time.Now().Add(-3 * epoch).Truncate(time.Second)
+	clock := newTestClock(t, genesis)
+
+	s := New(Options{
+		Interval:    time.Minute,
+		Jitter:      5 * time.Second,
+		EpochOffset: 30 * time.Second,
+		Clocks:      testClocks{clock: clock},
+	})
+	if err := s.SetAddresses([]models.Address{
+		{Address: "0x1"},
+		{Address: "0x2", Interval: "10s"},
+		{Address: "0x3", Interval: IntervalEpoch},
+	}); err != nil {
+		t.Fatalf("SetAddresses: %v", err)
+	}
+
+	now := genesis.Add(3*epoch + time.Minute)
+	due, timeout := s.takeDue(now)
+	if len(due) != 3 {
+		t.Fatalf("due = %+v, want every address", due)
+	}
+	// 기한은 가장 짧은 주기
+	if timeout != 10*time.Second {
+		t.Errorf("timeout = %s, want 10s", timeout)
+	}
+
+	tests := []struct {
+		address string
+		// next is the run before jitter
+		next time.Time
+	}{
+		{"0x1", now.Add(time.Minute)},
+		{"0x2", now.Add(10 * time.Second)},
+		{"0x3", genesis.Add(4*epoch + 30*time.Second)},
+	}
+	for _, tt := range tests {
+		got := s.entries[tt.address].next
+		if got.Before(tt.next) || !got.Before(tt.next.Add(5*time.Second)) {
+			t.Errorf("next run of %s = %s, want within the jitter after %s", tt.address, got, tt.next)
+		}
+	}
+
+	// 다음 실행 전에는 실행하지 않음
+	if due, _ := s.takeDue(now.Add(time.Second)); len(due) != 0 {
+		t.Errorf("due = %+v, want none before the next run", due)
+	}
+	if due, _ := s.takeDue(now.Add(15 * time.Second)); len(due) != 1 || due[0].Address != "0x2" {
+		t.Errorf("due = %+v, want only 0x2", due)
+	}
+}
+
+// testCycles collects the recorded cycles
+type testCycles struct {
+	mu        sync.Mutex
+	durations []float64
+	skipped   int
+}
+
+func (c *testCycles) RecordCycleDuration(duration float64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.durations = append(c.durations, duration)
+	return nil
+}
+
+func (c *testCycles) RecordCycleSkipped() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.skipped++
+	return nil
+}
+
+func TestRunOverlap(t *testing.T) {
+	tests := []struct {
+		overlap string
+		// skipped is whether cycles due during the slow cycle are skipped
+		skipped bool
+	}{
+		{OverlapQueue, false},
+		{OverlapSkip, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.overlap, func(t *testing.T) {
+			cycles := &testCycles{}
+			s := New(Options{Interval: 20 * time.Millisecond, Overlap: tt.overlap, Cycles: cycles})
+			if err := s.SetAddresses([]models.Address{{Address: "0x1"}}); err != nil {
+				t.Fatalf("SetAddresses: %v", err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// 첫 사이클은 주기보다 오래 걸림
+			var starts []time.Time
+			var finished time.Time
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				s.Run(ctx, func(ctx context.Context, due []models.Address) {
+					starts = append(starts, time.Now())
+					if len(starts) == 1 {
+						time.Sleep(100 * time.Millisecond)
+						finished = time.Now()
+						return
+					}
+					cancel()
+				})
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("Run did not return")
+			}
+
+			if len(starts) != 2 {
+				t.Fatalf("ran %d cycles, want 2", len(starts))
+			}
+			if skipped := cycles.skipped > 0; skipped != tt.skipped {
+				t.Errorf("skipped %d cycles, want skipped %v", cycles.skipped, tt.skipped)
+			}
+			// 대기열 정책에서는 느린 사이클이 끝나자마자 다음 사이클을 시작
+			if gap := starts[1].Sub(finished); !tt.skipped && gap > 15*time.Millisecond {
+				t.Errorf("queued cycle started %s after the slow one, want it right away", gap)
+			}
+			if len(cycles.durations) != 2 {
+				t.Errorf("recorded %d cycle durations, want 2", len(cycles.durations))
+			}
+		})
+	}
+}
+
+func TestRunDeadline(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		want time.Duration
+	}{
+		{"derived from the interval", Options{Interval: time.Minute}, time.Minute},
+		{"configured", Options{Interval: time.Minute, CycleTimeout: 10 * time.Second}, 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(tt.opts)
+			if err := s.SetAddresses([]models.Address{{Address: "0x1"}, {Address: "0x2", Interval: "2m"}}); err != nil {
+				t.Fatalf("SetAddresses: %v", err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			var deadline time.Time
+			var hasDeadline bool
+			start := time.Now()
+			s.Run(ctx, func(ctx context.Context, due []models.Address) {
+				deadline, hasDeadline = ctx.Deadline()
+				cancel()
+			})
+
+			if !hasDeadline {
+				t.Fatal("cycle has no deadline")
+			}
+			if got := deadline.Sub(start); got < tt.want || got > tt.want+time.Second {
+				t.Errorf("deadline = %s after the start, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,297 +2,470 @@ package service
 
 import (
 	"context"
+	"dill-monitor/internal/chainclock"
 	"dill-monitor/internal/models"
 	"dill-monitor/internal/repository"
-	"encoding/json"
+	"dill-monitor/internal/workerpool"
 	"fmt"
-	"io/ioutil"
 	"log"
-	"net/http"
-	"net/url"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 // BalanceService handles balance-related business logic
 type BalanceService struct {
-	repo repository.Repository
+	repo           repository.Repository
+	networks       map[string]*Network
+	defaultNetwork string
+	// latestEvents caches the latest event of every validator by network and
+	// index, loaded from the repository on first use; eventsMutex serializes
+	// the detection of validator status changes
+	latestEvents map[string]*models.ValidatorEvent
+	eventsMutex  sync.Mutex
 }
 
-// NewBalanceService creates a new balance service
-func NewBalanceService(repo repository.Repository) *BalanceService {
+// NewBalanceService creates a new balance service. Addresses that do not name
+// a network are processed on defaultNetwork.
+func NewBalanceService(repo repository.Repository, networks []*Network, defaultNetwork string) *BalanceService {
+	byName := make(map[string]*Network, len(networks))
+	for _, network := range networks {
+		if network.validators == nil {
+			network.validators = newValidatorResolver(network.API)
+		}
+		byName[network.Name] = network
+	}
+
 	return &BalanceService{
-		repo: repo,
+		repo:           repo,
+		networks:       byName,
+		defaultNetwork: defaultNetwork,
 	}
 }
 
-// ProcessAddress processes a single address and updates its balance information
-func (s *BalanceService) ProcessAddress(ctx context.Context, addr models.Address) (*models.Balance, error) {
-	// Get wallet balance
-	balance, err := s.getWalletBalance(addr.Address)
-	if err != nil {
-		return nil, fmt.Errorf("error getting wallet balance: %v", err)
+// network returns the network an address is monitored on
+func (s *BalanceService) network(addr models.Address) (*Network, error) {
+	name := addr.Network
+	if name == "" {
+		name = s.defaultNetwork
 	}
 
-	// Get staker info
-	stakerInfo, err := s.getStakerInfo(addr.Address)
-	if err != nil {
-		return nil, fmt.Errorf("error getting staker info: %v", err)
+	network, exists := s.networks[name]
+	if !exists {
+		return nil, fmt.Errorf("unknown network %q", name)
+	}
+	// 엔드포인트가 없는 내장 프로필은 주소가 사용할 때만 오류
+	if err := network.checkEndpoints(); err != nil {
+		return nil, err
 	}
+	return network, nil
+}
 
-	// Create balance object
-	balanceObj := &models.Balance{
-		Label:                 addr.Label,
-		Address:               addr.Address,
-		ValidatorAddress:      addr.ValidatorAddress,
-		Balance:               balance,
-		StakingBalance:        "0",
-		StakedAmount:          fmt.Sprintf("%.4f", float64(stakerInfo.StakedAmount)/1e9),
-		Reward:                fmt.Sprintf("%.4f", float64(stakerInfo.Reward)/1e9),
-		PoolCreatedCount:      stakerInfo.PoolCreatedCount,
-		PoolParticipatedCount: stakerInfo.PoolParticipatedCount,
-		LastEpoch:             "0",
-		LastRewardTime:        time.Now().Format(time.RFC3339),
-		LatestIncome:          "0",
-		DailyReward:           "0",
-	}
-
-	// If validator address exists, get validator info
-	if addr.ValidatorAddress != "" {
-		validatorInfo, err := s.getValidatorInfo(addr.ValidatorAddress)
-		if err != nil {
-			return nil, fmt.Errorf("error getting validator info: %v", err)
+// ValidateAddresses checks that every address is monitored on a configured
+// network with its endpoints set
+func (s *BalanceService) ValidateAddresses(addresses []models.Address) error {
+	for _, addr := range addresses {
+		if _, err := s.network(addr); err != nil {
+			return fmt.Errorf("address %s: %v", addr.Address, err)
 		}
+	}
+	return nil
+}
 
-		if validatorInfo != nil {
-			balanceObj.ValidatorIndex = validatorInfo.Index
-			balanceObj.Status = validatorInfo.Status
+// RemoveAddresses forgets the balances of addresses that are no longer
+// monitored and deletes their metric series
+func (s *BalanceService) RemoveAddresses(ctx context.Context, addresses []models.Address) error {
+	for _, addr := range addresses {
+		if err := s.repo.DeleteBalance(ctx, addr.Address); err != nil {
+			return fmt.Errorf("error deleting balance of %s: %v", addr.Address, err)
+		}
+	}
 
-			// Convert balance from string to float64
-			validatorBalance, err := strconv.ParseFloat(validatorInfo.Balance, 64)
-			if err == nil {
-				balanceObj.StakingBalance = fmt.Sprintf("%.3f", validatorBalance/1e9)
-			} else {
-				balanceObj.StakingBalance = "0"
-			}
+	balances, err := s.repo.ListBalances(ctx)
+	if err != nil {
+		return err
+	}
+	return s.repo.UpdateSummaryMetrics(ctx, balances)
+}
 
-			// Get validator details
-			if balanceObj.ValidatorIndex != "" {
-				details, err := s.getValidatorDetails(balanceObj.ValidatorIndex)
-				if err == nil && details != nil {
-					if len(details.Result.Data.JSON.EpochIdx) > 0 {
-						balanceObj.LastEpoch = details.Result.Data.JSON.EpochIdx[len(details.Result.Data.JSON.EpochIdx)-1]
-					}
-
-					if len(details.Result.Data.JSON.IncomeGWei) > 0 {
-						latestIncome := details.Result.Data.JSON.IncomeGWei[len(details.Result.Data.JSON.IncomeGWei)-1]
-						income, err := strconv.ParseFloat(latestIncome, 64)
-						if err == nil {
-							balanceObj.LatestIncome = fmt.Sprintf("%.4f", income/1e9)
-						}
-					}
-
-					if len(details.Result.Data.JSON.IncomeGweiDaySum) >= 2 {
-						dailyReward := float64(details.Result.Data.JSON.IncomeGweiDaySum[0] + details.Result.Data.JSON.IncomeGweiDaySum[1])
-						balanceObj.DailyReward = fmt.Sprintf("%.4f", dailyReward/1e9)
-					} else if len(details.Result.Data.JSON.IncomeGweiDaySum) == 1 {
-						// 하루 데이터만 있는 경우 그 값의 2배를 일일 보상 예상치로 사용
-						dailyReward := float64(details.Result.Data.JSON.IncomeGweiDaySum[0]) * 2
-						balanceObj.DailyReward = fmt.Sprintf("%.4f", dailyReward/1e9)
-					} else if len(details.Result.Data.JSON.IncomeGWei) > 0 {
-						// 일별 합계 데이터가 없지만 수입 데이터가 있는 경우, 마지막 수입 값에 기반하여 추정
-						lastIncome, err := strconv.ParseFloat(details.Result.Data.JSON.IncomeGWei[len(details.Result.Data.JSON.IncomeGWei)-1], 64)
-						if err == nil {
-							// 하루 동안 약 225개의 epoch가 발생한다고 가정 (예상치)
-							dailyReward := lastIncome * 225
-							balanceObj.DailyReward = fmt.Sprintf("%.4f", dailyReward/1e9)
-						} else {
-							balanceObj.DailyReward = balanceObj.LatestIncome // 단일 수입을 일일 보상으로 설정
-						}
-					} else {
-						log.Printf("Warning: No data available to calculate DailyReward for validator %s", balanceObj.ValidatorIndex)
-						balanceObj.DailyReward = "0"
-					}
-
-					balanceObj.LastRewardTime = time.Now().Format(time.RFC3339)
+// Reconcile brings the stored balances and exported series in line with the
+// configured addresses: balances of addresses that are no longer configured
+// or moved to another network are removed, balances whose label changed are
+// exported with the new label, and every series left over from an old label,
+// network or address is deleted.
+func (s *BalanceService) Reconcile(ctx context.Context, addresses []models.Address) error {
+	configured := make(map[string]models.Address, len(addresses))
+	resolved := make([]models.Address, 0, len(addresses))
+	for _, addr := range addresses {
+		network, err := s.network(addr)
+		if err != nil {
+			continue
+		}
+		addr.Network = network.Name
+		configured[addr.Address] = addr
+		resolved = append(resolved, addr)
+	}
 
-				}
+	balances, err := s.repo.ListBalances(ctx)
+	if err != nil {
+		return err
+	}
+
+	var removed []models.Address
+	for _, balance := range balances {
+		addr, exists := configured[balance.Address]
+		switch {
+		case !exists || balance.Network != addr.Network:
+			removed = append(removed, models.Address{Address: balance.Address})
+		case balance.Label != addr.Label:
+			// 다음 처리까지 기다리지 않고 새 라벨로 다시 내보냄
+			relabeled := *balance
+			relabeled.Label = addr.Label
+			if err := s.repo.SaveBalance(ctx, &relabeled); err != nil {
+				return fmt.Errorf("error relabeling balance of %s: %v", balance.Address, err)
 			}
 		}
-	} else {
-		// For addresses without validator, set current time for LastRewardTime
-		balanceObj.LastRewardTime = time.Now().Format(time.RFC3339)
-		log.Printf("Non-validator address %s: setting LastRewardTime to %s", addr.Address, balanceObj.LastRewardTime)
 	}
-
-	// Save balance to repository
-	if err := s.repo.SaveBalance(ctx, balanceObj); err != nil {
-		return nil, fmt.Errorf("error saving balance: %v", err)
+	if len(removed) > 0 {
+		log.Printf("Removing %d stored balances of addresses that are no longer configured", len(removed))
+		if err := s.RemoveAddresses(ctx, removed); err != nil {
+			return err
+		}
 	}
 
-	return balanceObj, nil
+	pruned, err := s.repo.PruneSeries(ctx, resolved)
+	if err != nil {
+		return err
+	}
+	if pruned > 0 {
+		log.Printf("Deleted %d stale metric series label sets", pruned)
+	}
+	return nil
 }
 
-// getWalletBalance retrieves the wallet balance from the API
-func (s *BalanceService) getWalletBalance(address string) (string, error) {
-	url := fmt.Sprintf("https://alps.dill.xyz/api/trpc/stats.getBalance?input={\"json\":{\"address\":\"%s\"}}", address)
-	resp, err := http.Get(url)
+// ProcessAddress processes a single address and updates its balance information.
+// Every source is fetched independently: a failed source keeps its last known
+// values, marked stale in balance.Sources, while the others are updated. The
+// balance is saved, checked for validator status changes and returned without
+// error as long as one source succeeded; when none did, the stale balance is
+// still saved and returned with an error.
+func (s *BalanceService) ProcessAddress(ctx context.Context, addr models.Address) (*models.Balance, error) {
+	network, err := s.network(addr)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
+	// 이전 값은 실패한 소스를 채우는 데 사용
+	previous, err := s.repo.GetBalance(ctx, addr.Address)
+	if err != nil || previous.Network != network.Name {
+		previous = nil
 	}
 
-	var response struct {
-		Result struct {
-			Data struct {
-				JSON struct {
-					Balance string `json:"balance"`
-				} `json:"json"`
-			} `json:"data"`
-		} `json:"result"`
+	var balanceObj *models.Balance
+	var stale []string
+	err = s.scrape(addr, network, models.SourceAddress, func() error {
+		balanceObj = s.processAddress(ctx, addr, network, previous)
+		if stale = staleSources(balanceObj); len(stale) > 0 {
+			return fmt.Errorf("failed to fetch %s", strings.Join(stale, ", "))
+		}
+		return nil
+	})
+
+	allFailed := len(stale) == len(balanceObj.Sources)
+	if allFailed && previous == nil {
+		// 알려진 값이 전혀 없으면 0으로 채운 메트릭을 내보내지 않음
+		return nil, err
 	}
 
-	if err := json.Unmarshal(body, &response); err != nil {
-		return "", err
+	if saveErr := s.repo.SaveBalance(ctx, balanceObj); saveErr != nil {
+		return nil, fmt.Errorf("error saving balance: %v", saveErr)
 	}
 
-	balance, err := strconv.ParseFloat(response.Result.Data.JSON.Balance, 64)
-	if err != nil {
-		return "", err
+	if allFailed {
+		return balanceObj, err
 	}
 
-	return fmt.Sprintf("%.10f DILL", balance/1e18), nil
+	// 상태 변경 기록 실패는 현재 값의 처리를 막지 않음
+	if eventErr := s.recordStatusChanges(ctx, network, balanceObj, time.Now()); eventErr != nil {
+		log.Printf("Error recording validator status changes of %s: %v", addr.Address, eventErr)
+	}
+	return balanceObj, nil
 }
 
-// getStakerInfo retrieves staker information from the API
-func (s *BalanceService) getStakerInfo(address string) (*models.StakerResponse, error) {
-	url := "https://staker.dill.xyz/api?Action=GetUserInfo"
-	requestBody := fmt.Sprintf(`{"Action":"GetUserInfo","Address":"%s"}`, address)
+// ProcessAddresses processes the addresses due in one cycle on pool, after
+// looking up their validators in batches, and appends the values fetched in
+// this cycle to the history. It returns the processed balances, including
+// those that only hold older values.
+func (s *BalanceService) ProcessAddresses(ctx context.Context, addresses []models.Address, pool *workerpool.Pool) []*models.Balance {
+	s.PrefetchValidators(ctx, addresses)
+
+	var mu sync.Mutex
+	var balances []*models.Balance
+	pool.Run(ctx, addresses, func(ctx context.Context, addr models.Address) {
+		balance, err := s.ProcessAddress(ctx, addr)
+		if err != nil {
+			log.Printf("Error processing address %s: %v", addr.Address, err)
+		}
+		if balance == nil {
+			return
+		}
+		log.Printf("Processed address %s: balance=%s, staking=%s, reward=%s",
+			addr.Address, balance.Balance, balance.StakingBalance, balance.Reward)
 
-	req, err := http.NewRequest("POST", url, strings.NewReader(requestBody))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("Content-Type", "application/json")
+		mu.Lock()
+		balances = append(balances, balance)
+		mu.Unlock()
+	})
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
+	// 이력 저장 실패는 메트릭 갱신을 막지 않음
+	if err := s.saveHistory(ctx, balances, time.Now()); err != nil {
+		log.Printf("Error saving history: %v", err)
 	}
-	defer resp.Body.Close()
+	return balances
+}
 
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+// saveHistory appends the balances processed in one cycle to the history,
+// writing every validator once even if it backs several of the addresses.
+// Values kept from earlier cycles are skipped.
+func (s *BalanceService) saveHistory(ctx context.Context, balances []*models.Balance, at time.Time) error {
+	snapshots := models.BalanceSnapshots(balances, at)
+	if len(snapshots) == 0 {
+		return nil
 	}
+	return s.repo.SaveSnapshots(ctx, snapshots)
+}
+
+// PrefetchValidators looks up the validators of the given addresses in
+// batches, one network at a time, so that processing them does not send one
+// lookup per address. Failed lookups are retried individually later. The
+// lookups of the previous cycle are dropped on every network.
+func (s *BalanceService) PrefetchValidators(ctx context.Context, addresses []models.Address) {
+	pubkeys := make(map[*Network][]string)
+	seen := make(map[string]bool)
+	for _, addr := range addresses {
+		network, err := s.network(addr)
+		if err != nil {
+			continue
+		}
+
+		// 인덱스로 설정된 검증자는 이전 주기에 확인된 pubkey로 조회
+		keys := addr.Pubkeys()
+		for _, index := range addr.ValidatorIndices {
+			if pubkey, exists := network.validators.Pubkey(strings.TrimSpace(index)); exists {
+				keys = append(keys, pubkey)
+			}
+		}
 
-	var stakerResponse models.StakerResponse
-	if err := json.Unmarshal(body, &stakerResponse); err != nil {
-		return &models.StakerResponse{
-			StakedAmount:          0,
-			Reward:                0,
-			PoolCreatedCount:      0,
-			PoolParticipatedCount: 0,
-		}, nil
+		for _, pubkey := range keys {
+			key := network.Name + "/" + models.NormalizePubkey(pubkey)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			pubkeys[network] = append(pubkeys[network], pubkey)
+		}
 	}
 
-	return &stakerResponse, nil
+	// 이번 주기에 조회할 검증자가 없는 네트워크도 이전 주기의 조회 결과를 비움
+	for _, network := range s.networks {
+		keys := pubkeys[network]
+		if err := network.validators.Prefetch(ctx, keys); err != nil {
+			log.Printf("Error prefetching %d validators on %s: %v", len(keys), network.Name, err)
+		}
+	}
 }
 
-// getValidatorInfo retrieves validator information from the API
-func (s *BalanceService) getValidatorInfo(validatorAddress string) (*models.ValidatorInfo, error) {
-	url := fmt.Sprintf("https://alps.dill.xyz/api/trpc/stats.getAllValidators?input={\"json\":{\"page\":1,\"limit\":25,\"pubkey\":\"%s\"}}", validatorAddress)
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+// processAddress fetches every source of an address on the given network,
+// falling back to the previous balance for the sources that fail
+func (s *BalanceService) processAddress(ctx context.Context, addr models.Address, network *Network, previous *models.Balance) *models.Balance {
+	gwei := network.gweiUnit()
+	now := time.Now()
 
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+	// Create balance object
+	balanceObj := &models.Balance{
+		Label:            addr.Label,
+		Address:          addr.Address,
+		Network:          network.Name,
+		ValidatorAddress: addr.ValidatorAddress,
+		StakingBalance:   "0",
+		StakedAmount:     "0",
+		Reward:           "0",
+		LastEpoch:        "0",
+		LastRewardTime:   now.Format(time.RFC3339),
+		LatestIncome:     "0",
+		DailyReward:      "0",
+		Sources:          make(map[string]models.SourceStatus),
 	}
 
-	var response struct {
-		Result struct {
-			Data struct {
-				JSON struct {
-					Data []models.ValidatorInfo `json:"data"`
-				} `json:"json"`
-			} `json:"data"`
-		} `json:"result"`
+	// Get wallet balance
+	err := s.scrape(addr, network, models.SourceBalance, func() error {
+		balance, err := s.getWalletBalance(ctx, network, addr.Address)
+		if err == nil {
+			balanceObj.Balance = balance
+		}
+		return err
+	})
+	s.resolveSource(balanceObj, previous, models.SourceBalance, now, err)
+
+	// Get staker info
+	err = s.scrape(addr, network, models.SourceStaker, func() error {
+		stakerInfo, err := network.API.GetStakerInfo(ctx, addr.Address)
+		if err == nil {
+			balanceObj.StakedAmount = fmt.Sprintf("%.4f", float64(stakerInfo.StakedAmount)/gwei)
+			balanceObj.Reward = fmt.Sprintf("%.4f", float64(stakerInfo.Reward)/gwei)
+			balanceObj.PoolCreatedCount = stakerInfo.PoolCreatedCount
+			balanceObj.PoolParticipatedCount = stakerInfo.PoolParticipatedCount
+		}
+		return err
+	})
+	s.resolveSource(balanceObj, previous, models.SourceStaker, now, err)
+
+	if !addr.HasValidators() {
+		// For addresses without validator, set current time for LastRewardTime
+		log.Printf("Non-validator address %s: setting LastRewardTime to %s", addr.Address, balanceObj.LastRewardTime)
+		return balanceObj
 	}
 
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, err
+	s.processValidators(ctx, addr, network, balanceObj, previous, now)
+	return balanceObj
+}
+
+// resolveSource records the outcome of a source in balance.Sources. When the
+// source failed, its fields are restored from the previous balance, if any.
+func (s *BalanceService) resolveSource(balance, previous *models.Balance, source string, now time.Time, err error) {
+	if err == nil {
+		balance.Sources[source] = models.SourceStatus{UpdatedAt: now.Format(time.RFC3339)}
+		return
 	}
 
-	if len(response.Result.Data.JSON.Data) > 0 {
-		return &response.Result.Data.JSON.Data[0], nil
+	status := models.SourceStatus{Stale: true, Error: err.Error()}
+	if previous != nil {
+		copySourceFields(balance, previous, source)
+		if prevStatus, exists := previous.Sources[source]; exists {
+			status.UpdatedAt = prevStatus.UpdatedAt
+		}
 	}
+	balance.Sources[source] = status
 
-	return nil, nil
+	log.Printf("Error fetching %s for address %s: %v", source, balance.Address, err)
 }
 
-// getValidatorDetails retrieves detailed validator information from the API
-func (s *BalanceService) getValidatorDetails(validatorIdx string) (*models.ValidatorDetailResponse, error) {
-	// Use UTC time to avoid timezone issues
-	now := time.Now().UTC()
-	endTime := now.UnixNano() / int64(time.Millisecond)
-	startTime := now.Add(-24*time.Hour).UnixNano() / int64(time.Millisecond)
+// copySourceFields copies the fields provided by an address-level source from
+// src to dst. Validator sources are restored per validator by copyValidatorFields.
+func copySourceFields(dst, src *models.Balance, source string) {
+	switch source {
+	case models.SourceBalance:
+		dst.Balance = src.Balance
+	case models.SourceStaker:
+		dst.StakedAmount = src.StakedAmount
+		dst.Reward = src.Reward
+		dst.PoolCreatedCount = src.PoolCreatedCount
+		dst.PoolParticipatedCount = src.PoolParticipatedCount
+	}
+}
 
-	inputJSON := fmt.Sprintf(`{"json":{"item":"only to meet the parameter requirements of tRPC","validatorKey":"%s","validatorIdx":"%s","validatorIsStr":false,"startTime":%d,"endTime":%d}}`,
-		validatorIdx, validatorIdx, startTime, endTime)
+// staleSources returns the sorted names of the sources that could not be fetched
+func staleSources(balance *models.Balance) []string {
+	var stale []string
+	for source, status := range balance.Sources {
+		if status.Stale {
+			stale = append(stale, source)
+		}
+	}
+	sort.Strings(stale)
+	return stale
+}
 
-	// URL 인코딩 적용
-	encodedInput := url.QueryEscape(inputJSON)
+// applyValidatorDetails derives epoch and income fields from the validator details
+func applyValidatorDetails(validator *models.ValidatorBalance, details *models.ValidatorDetailResponse, gwei, epochsPerDay float64) {
+	if len(details.Result.Data.JSON.EpochIdx) > 0 {
+		validator.LastEpoch = details.Result.Data.JSON.EpochIdx[len(details.Result.Data.JSON.EpochIdx)-1]
+	}
 
-	url := fmt.Sprintf("https://alps.dill.xyz/api/trpc/stats.getValidatorDetailByKeyOrIdx?input=%s", encodedInput)
+	if len(details.Result.Data.JSON.IncomeGWei) > 0 {
+		latestIncome := details.Result.Data.JSON.IncomeGWei[len(details.Result.Data.JSON.IncomeGWei)-1]
+		income, err := strconv.ParseFloat(latestIncome, 64)
+		if err == nil {
+			validator.LatestIncome = fmt.Sprintf("%.4f", income/gwei)
+		}
+	}
 
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("error making HTTP request for validator details %s: %v", validatorIdx, err)
+	if len(details.Result.Data.JSON.IncomeGweiDaySum) >= 2 {
+		dailyReward := float64(details.Result.Data.JSON.IncomeGweiDaySum[0] + details.Result.Data.JSON.IncomeGweiDaySum[1])
+		validator.DailyReward = fmt.Sprintf("%.4f", dailyReward/gwei)
+	} else if len(details.Result.Data.JSON.IncomeGweiDaySum) == 1 {
+		// 하루 데이터만 있는 경우 그 값의 2배를 일일 보상 예상치로 사용
+		dailyReward := float64(details.Result.Data.JSON.IncomeGweiDaySum[0]) * 2
+		validator.DailyReward = fmt.Sprintf("%.4f", dailyReward/gwei)
+	} else if len(details.Result.Data.JSON.IncomeGWei) > 0 {
+		// 일별 합계 데이터가 없지만 수입 데이터가 있는 경우, 마지막 수입 값에 기반하여 추정
+		lastIncome, err := strconv.ParseFloat(details.Result.Data.JSON.IncomeGWei[len(details.Result.Data.JSON.IncomeGWei)-1], 64)
+		if err == nil {
+			// 하루 동안 발생하는 epoch 수를 곱해 추정 (예상치)
+			dailyReward := lastIncome * epochsPerDay
+			validator.DailyReward = fmt.Sprintf("%.4f", dailyReward/gwei)
+		} else {
+			validator.DailyReward = validator.LatestIncome // 단일 수입을 일일 보상으로 설정
+		}
+	} else {
+		log.Printf("Warning: No data available to calculate DailyReward for validator %s", validator.Index)
+		validator.DailyReward = "0"
 	}
-	defer resp.Body.Close()
+}
 
-	// HTTP 상태 코드 확인
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("invalid HTTP status code %d for validator %s", resp.StatusCode, validatorIdx)
+// scrape runs fetch as one source of an address and records its outcome and duration
+func (s *BalanceService) scrape(addr models.Address, network *Network, source string, fetch func() error) error {
+	start := time.Now()
+	err := fetch()
+
+	if recordErr := s.repo.RecordScrape(addr.Address, addr.Label, network.Name, source, err == nil, time.Since(start).Seconds()); recordErr != nil {
+		log.Printf("Error recording scrape result for %s (%s): %v", addr.Address, source, recordErr)
 	}
 
-	body, err := ioutil.ReadAll(resp.Body)
+	return err
+}
+
+// getWalletBalance retrieves the wallet balance and formats it in DILL
+func (s *BalanceService) getWalletBalance(ctx context.Context, network *Network, address string) (string, error) {
+	rawBalance, err := network.API.GetBalance(ctx, address)
 	if err != nil {
-		return nil, fmt.Errorf("error reading response body for validator details %s: %v", validatorIdx, err)
+		return "", err
 	}
 
-	// Check for empty response
-	if len(body) == 0 {
-		return nil, fmt.Errorf("empty response body for validator details %s", validatorIdx)
+	balance, err := strconv.ParseFloat(rawBalance, 64)
+	if err != nil {
+		return "", err
 	}
 
-	// Check if the response is valid JSON
-	if !json.Valid(body) {
-		// 유효하지 않은 JSON이면 처음 100자만 로그로 출력
-		invalidJSON := string(body)
-		if len(invalidJSON) > 100 {
-			invalidJSON = invalidJSON[:100] + "..."
-		}
-		return nil, fmt.Errorf("invalid JSON response for validator details %s: %s", validatorIdx, invalidJSON)
+	return fmt.Sprintf("%.10f DILL", balance/network.weiUnit()), nil
+}
+
+// ChainClock implements scheduler.ClockSource
+func (s *BalanceService) ChainClock(addr models.Address) (*chainclock.Clock, error) {
+	network, err := s.network(addr)
+	if err != nil {
+		return nil, err
 	}
+	if network.Clock == nil {
+		return nil, fmt.Errorf("network %s has no genesisTime configured", network.Name)
+	}
+	return network.Clock, nil
+}
 
-	var detailResponse models.ValidatorDetailResponse
-	if err := json.Unmarshal(body, &detailResponse); err != nil {
-		return nil, fmt.Errorf("error parsing JSON response for validator details %s: %v", validatorIdx, err)
+// RecordNextRun implements scheduler.Recorder
+func (s *BalanceService) RecordNextRun(addr models.Address, next time.Time) error {
+	network := addr.Network
+	if network == "" {
+		network = s.defaultNetwork
 	}
+	return s.repo.RecordNextRun(addr.Address, addr.Label, network, next)
+}
 
-	return &detailResponse, nil
+// ListBalances returns the latest balance of every processed address
+func (s *BalanceService) ListBalances(ctx context.Context) ([]*models.Balance, error) {
+	return s.repo.ListBalances(ctx)
 }
 
 // UpdateSummaryMetrics updates the summary metrics with aggregated data from all balances
@@ -315,35 +488,33 @@ func (s *BalanceService) ProcessValidators(ctx context.Context) error {
 
 	// Process each validator
 	for _, balance := range balances {
-		// Skip non-validators
-		if balance.ValidatorIndex == "" || strings.TrimSpace(balance.ValidatorIndex) == "" {
-			continue
-		}
-
-		validatorCount++
-
-		// Create validator reward object
-		validatorReward := &models.ValidatorReward{
-			ValidatorIdx: balance.ValidatorIndex,
-			LastEpoch:    balance.LastEpoch,
-			Date:         balance.LastRewardTime,
-			Status:       balance.Status,
-			Balance:      balance.StakingBalance,
-			UserLabel:    balance.Label,
-		}
+		for _, validator := range balance.KnownValidators() {
+			validatorCount++
+
+			// Create validator reward object
+			validatorReward := &models.ValidatorReward{
+				ValidatorIdx: validator.Index,
+				LastEpoch:    validator.LastEpoch,
+				Date:         validator.LastRewardTime,
+				Status:       validator.Status,
+				Balance:      validator.StakingBalance,
+				UserLabel:    balance.Label,
+				Network:      balance.Network,
+			}
 
-		// Parse LastReward
-		lastReward := 0.0
-		if balance.LatestIncome != "" {
-			if val, err := strconv.ParseFloat(balance.LatestIncome, 64); err == nil {
-				lastReward = val
+			// Parse LastReward
+			lastReward := 0.0
+			if validator.LatestIncome != "" {
+				if val, err := strconv.ParseFloat(validator.LatestIncome, 64); err == nil {
+					lastReward = val
+				}
 			}
-		}
-		validatorReward.LastReward = lastReward
+			validatorReward.LastReward = lastReward
 
-		// Update validator reward
-		if err := s.repo.SaveValidatorReward(ctx, validatorReward); err != nil {
-			log.Printf("Error saving validator reward: %v", err)
+			// Update validator reward
+			if err := s.repo.SaveValidatorReward(ctx, validatorReward); err != nil {
+				log.Printf("Error saving validator reward: %v", err)
+			}
 		}
 	}
 
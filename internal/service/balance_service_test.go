@@ -0,0 +1,510 @@
+package service
+
+import (
+	"context"
+	"dill-monitor/internal/dillapi"
+	"dill-monitor/internal/models"
+	"dill-monitor/internal/repository"
+	"dill-monitor/internal/workerpool"
+	"dill-monitor/pkg/metrics"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	testAddress = "0x1111111111111111111111111111111111111111"
+	testPubkey  = "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+)
+
+// metrics are registered globally, so every test shares one client
+var testMetrics = metrics.NewPrometheusClient()
+
+// scrapeRecorder is a repository that remembers the scrape outcome of every source
+type scrapeRecorder struct {
+	*repository.PrometheusRepository
+
+	mu        sync.Mutex
+	scrapes   map[string]bool
+	snapshots []*models.Snapshot
+	events    []*models.ValidatorEvent
+}
+
+func newScrapeRecorder() *scrapeRecorder {
+	return &scrapeRecorder{
+		PrometheusRepository: repository.NewPrometheusRepository(testMetrics),
+		scrapes:              make(map[string]bool),
+	}
+}
+
+func (r *scrapeRecorder) RecordScrape(address, label, network, source string, success bool, duration float64) error {
+	r.mu.Lock()
+	r.scrapes[source] = success
+	r.mu.Unlock()
+	return r.PrometheusRepository.RecordScrape(address, label, network, source, success, duration)
+}
+
+func (r *scrapeRecorder) SaveSnapshots(ctx context.Context, snapshots []*models.Snapshot) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.snapshots = append(r.snapshots, snapshots...)
+	return nil
+}
+
+func (r *scrapeRecorder) SaveValidatorEvent(ctx context.Context, event *models.ValidatorEvent) error {
+	r.mu.Lock()
+	r.events = append(r.events, event)
+	r.mu.Unlock()
+	return r.PrometheusRepository.SaveValidatorEvent(ctx, event)
+}
+
+// newTestService returns a balance service on a single "alps" network backed by api
+func newTestService(repo repository.Repository, api dillapi.DillAPI) *BalanceService {
+	network := &Network{
+		Name: "alps",
+		Profile: models.NetworkProfile{
+			ExplorerURL:    "https://explorer.example.com/api/trpc",
+			StakerURL:      "https://staker.example.com/api",
+			GweiDecimals:   9,
+			WeiDecimals:    18,
+			SecondsPerSlot: 12,
+			SlotsPerEpoch:  32,
+		},
+		API: api,
+	}
+	return NewBalanceService(repo, []*Network{network}, "alps")
+}
+
+// validatorDetails builds a details response with the given epochs and daily income sums
+func validatorDetails(t *testing.T, pubkey string, epochs []string, daySums []int64) *models.ValidatorDetailResponse {
+	t.Helper()
+
+	data, err := json.Marshal(map[string]interface{}{
+		"result": map[string]interface{}{
+			"data": map[string]interface{}{
+				"json": map[string]interface{}{
+					"validatorPublicKey": pubkey,
+					"epochIdx":           epochs,
+					"incomeGWei":         []string{"20000000"},
+					"incomeGweiDaySum":   daySums,
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var details models.ValidatorDetailResponse
+	if err := json.Unmarshal(data, &details); err != nil {
+		t.Fatal(err)
+	}
+	return &details
+}
+
+func TestProcessAddress(t *testing.T) {
+	tests := []struct {
+		name  string
+		addr  models.Address
+		setup func(t *testing.T, api *dillapi.FakeClient)
+		check func(t *testing.T, balance *models.Balance)
+	}{
+		{
+			name: "wallet and staker",
+			addr: models.Address{Label: "wallet", Address: testAddress},
+			setup: func(t *testing.T, api *dillapi.FakeClient) {
+				api.Balances[testAddress] = "2500000000000000000"
+				api.Stakers[testAddress] = &models.StakerResponse{StakedAmount: 3600000000000, Reward: 1500000000, PoolCreatedCount: 1}
+			},
+			check: func(t *testing.T, balance *models.Balance) {
+				if balance.Balance != "2.5000000000 DILL" {
+					t.Errorf("balance = %q, want %q", balance.Balance, "2.5000000000 DILL")
+				}
+				if balance.StakedAmount != "3600.0000" || balance.Reward != "1.5000" {
+					t.Errorf("staked amount, reward = %q, %q, want %q, %q", balance.StakedAmount, balance.Reward, "3600.0000", "1.5000")
+				}
+				if balance.PoolCreatedCount != 1 {
+					t.Errorf("pool created count = %d, want 1", balance.PoolCreatedCount)
+				}
+				if len(balance.Validators) != 0 {
+					t.Errorf("validators = %v, want none", balance.Validators)
+				}
+			},
+		},
+		{
+			name: "validator by pubkey",
+			addr: models.Address{Label: "validator", Address: testAddress, ValidatorAddress: testPubkey},
+			setup: func(t *testing.T, api *dillapi.FakeClient) {
+				api.Balances[testAddress] = "0"
+				api.Validators[testPubkey] = &models.ValidatorInfo{Pubkey: testPubkey, Index: "17021", Status: "active_ongoing", Balance: "3600500000000"}
+				api.Details["17021"] = validatorDetails(t, testPubkey, []string{"57005", "57006"}, []int64{100000000, 200000000})
+			},
+			check: func(t *testing.T, balance *models.Balance) {
+				if len(balance.Validators) != 1 {
+					t.Fatalf("validators = %v, want one", balance.Validators)
+				}
+				validator := balance.Validators[0]
+				if validator.Index != "17021" || validator.Status != "active_ongoing" {
+					t.Errorf("index, status = %q, %q, want %q, %q", validator.Index, validator.Status, "17021", "active_ongoing")
+				}
+				if balance.StakingBalance != "3600.500" {
+					t.Errorf("staking balance = %q, want %q", balance.StakingBalance, "3600.500")
+				}
+				if balance.LastEpoch != "57006" || balance.DailyReward != "0.3000" || balance.LatestIncome != "0.0200" {
+					t.Errorf("last epoch, daily reward, latest income = %q, %q, %q, want %q, %q, %q",
+						balance.LastEpoch, balance.DailyReward, balance.LatestIncome, "57006", "0.3000", "0.0200")
+				}
+			},
+		},
+		{
+			name: "validator by index learns the pubkey",
+			addr: models.Address{Label: "index", Address: testAddress, ValidatorIndices: []string{"42"}},
+			setup: func(t *testing.T, api *dillapi.FakeClient) {
+				api.Details["42"] = validatorDetails(t, testPubkey, []string{"100"}, []int64{50000000})
+			},
+			check: func(t *testing.T, balance *models.Balance) {
+				if len(balance.Validators) != 1 || balance.Validators[0].Pubkey != testPubkey {
+					t.Fatalf("validators = %v, want one with pubkey %s", balance.Validators, testPubkey)
+				}
+				// 하루 합계만 있으면 두 배를 일일 보상으로 추정
+				if balance.DailyReward != "0.1000" {
+					t.Errorf("daily reward = %q, want %q", balance.DailyReward, "0.1000")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := dillapi.NewFakeClient()
+			tt.setup(t, api)
+			service := newTestService(newScrapeRecorder(), api)
+
+			balance, err := service.ProcessAddress(context.Background(), tt.addr)
+			if err != nil {
+				t.Fatalf("ProcessAddress: %v", err)
+			}
+			if balance.Network != "alps" {
+				t.Errorf("network = %q, want %q", balance.Network, "alps")
+			}
+			if stale := staleSources(balance); len(stale) != 0 {
+				t.Errorf("stale sources = %v, want none", stale)
+			}
+			tt.check(t, balance)
+		})
+	}
+}
+
+func TestProcessAddressUnknownNetwork(t *testing.T) {
+	service := newTestService(newScrapeRecorder(), dillapi.NewFakeClient())
+
+	_, err := service.ProcessAddress(context.Background(), models.Address{Address: testAddress, Network: "andes"})
+	if err == nil || !strings.Contains(err.Error(), "unknown network") {
+		t.Fatalf("ProcessAddress error = %v, want unknown network", err)
+	}
+}
+
+func TestValidateAddresses(t *testing.T) {
+	service := newTestService(newScrapeRecorder(), dillapi.NewFakeClient())
+	// 엔드포인트가 없는 네트워크는 설정되어 있어도 주소가 사용할 때만 거부
+	service.networks["mainnet"] = &Network{Name: "mainnet", API: dillapi.NewFakeClient()}
+
+	tests := []struct {
+		name      string
+		addresses []models.Address
+		err       string
+	}{
+		{"default network", []models.Address{{Address: testAddress}}, ""},
+		{"network set on the address", []models.Address{{Address: testAddress, Network: "alps"}}, ""},
+		{"network without endpoints", []models.Address{{Address: testAddress, Network: "mainnet"}}, `network "mainnet" has no explorerUrl configured`},
+		{"unknown network", []models.Address{{Address: testAddress, Network: "andes"}}, `unknown network "andes"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.ValidateAddresses(tt.addresses)
+			if tt.err == "" {
+				if err != nil {
+					t.Fatalf("ValidateAddresses: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("ValidateAddresses error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestProcessAddressKeepsPreviousValues(t *testing.T) {
+	api := dillapi.NewFakeClient()
+	api.Balances[testAddress] = "1000000000000000000"
+	api.Stakers[testAddress] = &models.StakerResponse{StakedAmount: 5000000000}
+	service := newTestService(newScrapeRecorder(), api)
+	addr := models.Address{Label: "wallet", Address: testAddress}
+
+	if _, err := service.ProcessAddress(context.Background(), addr); err != nil {
+		t.Fatalf("first ProcessAddress: %v", err)
+	}
+
+	// 모든 소스가 실패하면 이전 값을 오래된 값으로 표시해 유지
+	api.Errors[testAddress] = errors.New("upstream down")
+	balance, err := service.ProcessAddress(context.Background(), addr)
+	if err == nil {
+		t.Fatal("second ProcessAddress succeeded, want an error")
+	}
+	if balance == nil {
+		t.Fatal("second ProcessAddress returned no balance, want the previous values")
+	}
+	if balance.Balance != "1.0000000000 DILL" || balance.StakedAmount != "5.0000" {
+		t.Errorf("balance, staked amount = %q, %q, want the previous %q, %q", balance.Balance, balance.StakedAmount, "1.0000000000 DILL", "5.0000")
+	}
+	for _, source := range []string{models.SourceBalance, models.SourceStaker} {
+		if status := balance.Sources[source]; !status.Stale || status.UpdatedAt == "" {
+			t.Errorf("source %s = %+v, want stale with the previous update time", source, status)
+		}
+	}
+}
+
+func TestProcessAddressWithoutKnownValues(t *testing.T) {
+	api := dillapi.NewFakeClient()
+	api.Errors[testAddress] = errors.New("upstream down")
+	service := newTestService(newScrapeRecorder(), api)
+
+	balance, err := service.ProcessAddress(context.Background(), models.Address{Address: testAddress})
+	if err == nil || balance != nil {
+		t.Fatalf("ProcessAddress = %v, %v, want no balance and an error", balance, err)
+	}
+}
+
+func TestProcessAddressScrapeHealth(t *testing.T) {
+	tests := []struct {
+		name   string
+		errors []string
+		want   map[string]bool
+	}{
+		{
+			name: "all sources succeed",
+			want: map[string]bool{
+				models.SourceAddress: true, models.SourceBalance: true, models.SourceStaker: true,
+				models.SourceValidator: true, models.SourceValidatorDetail: true,
+			},
+		},
+		{
+			// 인덱스를 모르면 상세 정보는 조회하지 않음
+			name:   "validator lookup fails",
+			errors: []string{testPubkey},
+			want: map[string]bool{
+				models.SourceAddress: false, models.SourceBalance: true, models.SourceStaker: true,
+				models.SourceValidator: false,
+			},
+		},
+		{
+			name:   "validator details fail",
+			errors: []string{"17021"},
+			want: map[string]bool{
+				models.SourceAddress: false, models.SourceBalance: true, models.SourceStaker: true,
+				models.SourceValidator: true, models.SourceValidatorDetail: false,
+			},
+		},
+		{
+			name:   "address sources fail",
+			errors: []string{testAddress},
+			want: map[string]bool{
+				models.SourceAddress: false, models.SourceBalance: false, models.SourceStaker: false,
+				models.SourceValidator: true, models.SourceValidatorDetail: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := dillapi.NewFakeClient()
+			api.Balances[testAddress] = "1000000000000000000"
+			api.Validators[testPubkey] = &models.ValidatorInfo{Pubkey: testPubkey, Index: "17021", Status: "active_ongoing", Balance: "3600000000000"}
+			api.Details["17021"] = validatorDetails(t, testPubkey, []string{"57006"}, []int64{100000000})
+			for _, key := range tt.errors {
+				api.Errors[key] = errors.New("upstream down")
+			}
+
+			repo := newScrapeRecorder()
+			service := newTestService(repo, api)
+			// 일부 소스만 실패하면 주소 처리는 성공
+			if _, err := service.ProcessAddress(context.Background(), models.Address{Address: testAddress, ValidatorAddress: testPubkey}); err != nil {
+				t.Fatalf("ProcessAddress: %v", err)
+			}
+
+			if !reflect.DeepEqual(repo.scrapes, tt.want) {
+				t.Errorf("scrapes = %v, want %v", repo.scrapes, tt.want)
+			}
+
+			balance, err := repo.GetBalance(context.Background(), testAddress)
+			if err != nil {
+				t.Fatalf("GetBalance: %v", err)
+			}
+			for source, want := range tt.want {
+				if source == models.SourceAddress {
+					continue
+				}
+				if stale := balance.Sources[source].Stale; stale == want {
+					t.Errorf("source %s stale = %v, want %v", source, stale, !want)
+				}
+			}
+		})
+	}
+}
+
+func TestProcessAddressesHistory(t *testing.T) {
+	const otherAddress = "0x2222222222222222222222222222222222222222"
+
+	api := dillapi.NewFakeClient()
+	api.Balances[testAddress] = "1000000000000000000"
+	api.Balances[otherAddress] = "2000000000000000000"
+	api.Validators[testPubkey] = &models.ValidatorInfo{Pubkey: testPubkey, Index: "17021", Status: "active_ongoing", Balance: "3600000000000"}
+	api.Details["17021"] = validatorDetails(t, testPubkey, []string{"57006"}, []int64{100000000})
+
+	repo := newScrapeRecorder()
+	service := newTestService(repo, api)
+	pool := workerpool.New(1, nil)
+	// 두 주소가 같은 검증자를 공유
+	addresses := []models.Address{
+		{Label: "a", Address: testAddress, ValidatorAddress: testPubkey},
+		{Label: "b", Address: otherAddress, ValidatorAddresses: []string{testPubkey}},
+	}
+
+	tests := []struct {
+		name   string
+		errors []string
+		// balances is the number of processed balances returned
+		balances int
+		want     []string
+	}{
+		{"every source fresh", nil, 2, []string{"address:" + testAddress, "address:" + otherAddress, "validator:17021"}},
+		// 실패한 소스의 이전 값은 새 기록이 아님
+		{"address source stale", []string{otherAddress}, 2, []string{"address:" + testAddress, "validator:17021"}},
+		{"validator stale", []string{"17021"}, 2, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api.Errors = make(map[string]error)
+			for _, key := range tt.errors {
+				api.Errors[key] = errors.New("upstream down")
+			}
+			repo.snapshots = nil
+
+			if balances := service.ProcessAddresses(context.Background(), addresses, pool); len(balances) != tt.balances {
+				t.Errorf("processed %d balances, want %d", len(balances), tt.balances)
+			}
+
+			var got []string
+			for _, snapshot := range repo.snapshots {
+				got = append(got, snapshot.Subject+":"+snapshot.Key)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("snapshots = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordStatusChanges(t *testing.T) {
+	api := dillapi.NewFakeClient()
+	api.Balances[testAddress] = "0"
+	api.Details["17021"] = validatorDetails(t, testPubkey, []string{"57006"}, []int64{100000000})
+	repo := newScrapeRecorder()
+	service := newTestService(repo, api)
+	addr := models.Address{Label: "a", Address: testAddress, ValidatorAddress: testPubkey}
+
+	type event struct{ kind, from, to string }
+	tests := []struct {
+		name   string
+		status string
+		// fail makes the validator lookup fail, leaving it stale
+		fail bool
+		want []event
+	}{
+		{"first observation", "pending_queued", false, []event{{models.EventObserved, "", "pending_queued"}}},
+		{"unchanged", "pending_queued", false, nil},
+		{"activated", "active_ongoing", false, []event{{models.EventActivated, "pending_queued", "active_ongoing"}}},
+		// 오래된 값의 상태는 변경으로 기록하지 않음
+		{"stale", "active_exiting", true, nil},
+		{"exiting", "active_exiting", false, []event{{models.EventExiting, "active_ongoing", "active_exiting"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api.Validators[testPubkey] = &models.ValidatorInfo{Pubkey: testPubkey, Index: "17021", Status: tt.status, Balance: "3600000000000"}
+			api.Errors = make(map[string]error)
+			if tt.fail {
+				api.Errors[testPubkey] = errors.New("upstream down")
+			}
+			repo.events = nil
+
+			if _, err := service.ProcessAddress(context.Background(), addr); err != nil {
+				t.Fatalf("ProcessAddress: %v", err)
+			}
+
+			var got []event
+			for _, e := range repo.events {
+				if e.ValidatorIdx != "17021" || e.Network != "alps" || e.Label != "a" {
+					t.Errorf("event of %s on %s (%s), want validator 17021 on alps (a)", e.ValidatorIdx, e.Network, e.Label)
+				}
+				got = append(got, event{e.Kind, e.From, e.To})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("events = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	repo := newScrapeRecorder()
+	service := newTestService(repo, dillapi.NewFakeClient())
+	ctx := context.Background()
+
+	const (
+		relabeled = "0x2222222222222222222222222222222222222222"
+		removed   = "0x3333333333333333333333333333333333333333"
+		moved     = "0x4444444444444444444444444444444444444444"
+	)
+	for _, balance := range []*models.Balance{
+		{Address: testAddress, Label: "kept", Network: "alps"},
+		{Address: relabeled, Label: "old", Network: "alps"},
+		{Address: removed, Label: "removed", Network: "alps"},
+		{Address: moved, Label: "moved", Network: "andes"},
+	} {
+		if err := repo.SaveBalance(ctx, balance); err != nil {
+			t.Fatalf("SaveBalance: %v", err)
+		}
+	}
+
+	// 네트워크를 생략한 주소는 기본 네트워크로 비교
+	err := service.Reconcile(ctx, []models.Address{
+		{Address: testAddress, Label: "kept"},
+		{Address: relabeled, Label: "new", Network: "alps"},
+		{Address: moved, Label: "moved", Network: "alps"},
+	})
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	balances, err := repo.ListBalances(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]string)
+	for _, balance := range balances {
+		got[balance.Address] = balance.Label
+	}
+	want := map[string]string{testAddress: "kept", relabeled: "new"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("balances = %v, want %v", got, want)
+	}
+}
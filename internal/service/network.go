@@ -0,0 +1,75 @@
+package service
+
+import (
+	"dill-monitor/internal/chainclock"
+	"dill-monitor/internal/dillapi"
+	"dill-monitor/internal/models"
+	"fmt"
+	"math"
+	"time"
+)
+
+// defaultEpochsPerDay is used to estimate daily rewards when the epoch length is unknown
+const defaultEpochsPerDay = 225
+
+// Network binds a network profile to the client used to query it
+type Network struct {
+	Name    string
+	Profile models.NetworkProfile
+	API     dillapi.DillAPI
+	// Clock is nil when the profile has no genesis time
+	Clock *chainclock.Clock
+
+	// validators is set up by the balance service that uses the network
+	validators *validatorResolver
+}
+
+// NewNetwork creates a network backed by the default HTTP client for the profile
+func NewNetwork(name string, profile models.NetworkProfile, opts dillapi.Options) (*Network, error) {
+	network := &Network{
+		Name:    name,
+		Profile: profile,
+		API:     dillapi.NewHTTPClient(profile.ExplorerURL, profile.StakerURL, opts),
+	}
+
+	if profile.GenesisTime != 0 {
+		clock, err := chainclock.New(time.Unix(profile.GenesisTime, 0), profile.SecondsPerSlot, profile.SlotsPerEpoch)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chain clock for network %s: %v", name, err)
+		}
+		network.Clock = clock
+	}
+
+	return network, nil
+}
+
+// checkEndpoints reports whether the profile has the endpoints needed to
+// monitor addresses on the network
+func (n *Network) checkEndpoints() error {
+	if n.Profile.ExplorerURL == "" {
+		return fmt.Errorf("network %q has no explorerUrl configured", n.Name)
+	}
+	if n.Profile.StakerURL == "" {
+		return fmt.Errorf("network %q has no stakerUrl configured", n.Name)
+	}
+	return nil
+}
+
+// gweiUnit returns the divisor that converts Gwei denominated values to DILL
+func (n *Network) gweiUnit() float64 {
+	return math.Pow10(n.Profile.GweiDecimals)
+}
+
+// weiUnit returns the divisor that converts Wei denominated values to DILL
+func (n *Network) weiUnit() float64 {
+	return math.Pow10(n.Profile.WeiDecimals)
+}
+
+// epochsPerDay returns the number of epochs in a day
+func (n *Network) epochsPerDay() float64 {
+	epochSeconds := n.Profile.SecondsPerSlot * n.Profile.SlotsPerEpoch
+	if epochSeconds == 0 {
+		return defaultEpochsPerDay
+	}
+	return float64(24*time.Hour/time.Second) / float64(epochSeconds)
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"dill-monitor/internal/dillapi"
+	"dill-monitor/internal/models"
+	"sync"
+)
+
+// validatorResolver looks up validators by pubkey on one network. The lookups
+// of a cycle are prefetched in batches and shared by the addresses being
+// processed, and the index of every validator found is cached since it never
+// changes once assigned.
+type validatorResolver struct {
+	api dillapi.DillAPI
+
+	mu sync.Mutex
+	// indices maps normalized pubkeys to validator indices
+	indices map[string]string
+	// pubkeys maps validator indices to pubkeys
+	pubkeys map[string]string
+	// prefetched holds the lookups of the current cycle by normalized pubkey;
+	// a nil value means the validator was looked up but not found
+	prefetched map[string]*models.ValidatorInfo
+}
+
+// newValidatorResolver creates a resolver backed by api
+func newValidatorResolver(api dillapi.DillAPI) *validatorResolver {
+	return &validatorResolver{
+		api:        api,
+		indices:    make(map[string]string),
+		pubkeys:    make(map[string]string),
+		prefetched: make(map[string]*models.ValidatorInfo),
+	}
+}
+
+// Prefetch looks up the given pubkeys in batches, replacing the lookups of
+// the previous cycle even if there are none to make. Pubkeys that could not
+// be looked up are resolved one by one when they are needed.
+func (r *validatorResolver) Prefetch(ctx context.Context, pubkeys []string) error {
+	if len(pubkeys) == 0 {
+		r.mu.Lock()
+		r.prefetched = make(map[string]*models.ValidatorInfo)
+		r.mu.Unlock()
+		return nil
+	}
+
+	validators, err := r.api.GetValidatorsByPubkeys(ctx, pubkeys)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.prefetched = make(map[string]*models.ValidatorInfo, len(pubkeys))
+
+	for _, pubkey := range pubkeys {
+		validator, found := validators[pubkey]
+		if !found && err != nil {
+			// 배치 조회가 중간에 실패한 경우 나머지는 개별 조회로 처리
+			continue
+		}
+		r.prefetched[models.NormalizePubkey(pubkey)] = validator
+		r.remember(pubkey, validator)
+	}
+	return err
+}
+
+// Resolve returns the validator registered under pubkey, or nil if the
+// explorer does not know it, using the prefetched lookup when there is one
+func (r *validatorResolver) Resolve(ctx context.Context, pubkey string) (*models.ValidatorInfo, error) {
+	r.mu.Lock()
+	validator, prefetched := r.prefetched[models.NormalizePubkey(pubkey)]
+	r.mu.Unlock()
+
+	if prefetched {
+		return validator, nil
+	}
+
+	validator, err := r.api.GetValidatorInfo(ctx, pubkey)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.remember(pubkey, validator)
+	r.mu.Unlock()
+
+	return validator, nil
+}
+
+// Index returns the cached index of the validator registered under pubkey
+func (r *validatorResolver) Index(pubkey string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	index, exists := r.indices[models.NormalizePubkey(pubkey)]
+	return index, exists
+}
+
+// Pubkey returns the cached pubkey of the validator with the given index
+func (r *validatorResolver) Pubkey(index string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pubkey, exists := r.pubkeys[index]
+	return pubkey, exists
+}
+
+// Learn caches the pubkey and index of a validator found by other means,
+// such as the validator details
+func (r *validatorResolver) Learn(pubkey, index string) {
+	if pubkey == "" || index == "" {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.indices[models.NormalizePubkey(pubkey)] = index
+	r.pubkeys[index] = pubkey
+}
+
+// remember caches the index of a validator; callers must hold r.mu
+func (r *validatorResolver) remember(pubkey string, validator *models.ValidatorInfo) {
+	if validator != nil && validator.Index != "" {
+		r.indices[models.NormalizePubkey(pubkey)] = validator.Index
+		r.pubkeys[validator.Index] = pubkey
+	}
+}
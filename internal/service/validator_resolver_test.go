@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"dill-monitor/internal/dillapi"
+	"dill-monitor/internal/models"
+	"testing"
+)
+
+func TestValidatorResolverPrefetch(t *testing.T) {
+	api := dillapi.NewFakeClient()
+	api.Validators[testPubkey] = &models.ValidatorInfo{Pubkey: testPubkey, Index: "17021", Status: "active_ongoing"}
+	resolver := newValidatorResolver(api)
+	ctx := context.Background()
+
+	if err := resolver.Prefetch(ctx, []string{testPubkey}); err != nil {
+		t.Fatalf("Prefetch: %v", err)
+	}
+	if validator, err := resolver.Resolve(ctx, testPubkey); err != nil || validator == nil || validator.Status != "active_ongoing" {
+		t.Fatalf("Resolve = %+v, %v, want the prefetched validator", validator, err)
+	}
+	if calls := api.Calls("GetValidatorInfo", testPubkey); calls != 0 {
+		t.Errorf("GetValidatorInfo calls = %d, want the prefetched lookup used", calls)
+	}
+	if index, _ := resolver.Index(testPubkey); index != "17021" {
+		t.Errorf("Index = %q, want 17021", index)
+	}
+
+	// 다음 주기에 조회할 검증자가 없어도 이전 결과는 현재 값으로 쓰지 않음
+	api.Validators[testPubkey] = &models.ValidatorInfo{Pubkey: testPubkey, Index: "17021", Status: "exited"}
+	if err := resolver.Prefetch(ctx, nil); err != nil {
+		t.Fatalf("empty Prefetch: %v", err)
+	}
+	if validator, err := resolver.Resolve(ctx, testPubkey); err != nil || validator == nil || validator.Status != "exited" {
+		t.Errorf("Resolve = %+v, %v, want the validator looked up again", validator, err)
+	}
+	if calls := api.Calls("GetValidatorsByPubkeys", testPubkey); calls != 1 {
+		t.Errorf("GetValidatorsByPubkeys calls = %d, want only the first prefetch", calls)
+	}
+}
+
+func TestPrefetchValidatorsEveryNetwork(t *testing.T) {
+	alps := dillapi.NewFakeClient()
+	alps.Validators[testPubkey] = &models.ValidatorInfo{Pubkey: testPubkey, Index: "17021", Status: "active_ongoing"}
+	andes := dillapi.NewFakeClient()
+	service := NewBalanceService(newScrapeRecorder(), []*Network{
+		{Name: "alps", API: alps},
+		{Name: "andes", API: andes},
+	}, "alps")
+	ctx := context.Background()
+
+	service.PrefetchValidators(ctx, []models.Address{{Address: testAddress, ValidatorAddress: testPubkey}})
+	// 다음 주기에는 andes 주소만 처리
+	service.PrefetchValidators(ctx, []models.Address{{Address: testAddress, Network: "andes"}})
+
+	if _, err := service.networks["alps"].validators.Resolve(ctx, testPubkey); err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if calls := alps.Calls("GetValidatorInfo", testPubkey); calls != 1 {
+		t.Errorf("GetValidatorInfo calls = %d, want the lookup of the earlier cycle dropped", calls)
+	}
+}
@@ -0,0 +1,328 @@
+package service
+
+import (
+	"context"
+	"dill-monitor/internal/models"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// processValidators fetches every validator backing an address and aggregates
+// them into the address-level validator fields. A validator that cannot be
+// fetched keeps its previous values and is marked stale, as is the source.
+func (s *BalanceService) processValidators(ctx context.Context, addr models.Address, network *Network, balanceObj, previous *models.Balance, now time.Time) {
+	gwei := network.gweiUnit()
+	validators := s.newValidators(addr, network, now)
+
+	// Look up status, balance and index of the validators with a known pubkey
+	err := s.scrape(addr, network, models.SourceValidator, func() error {
+		var errs []string
+		for i := range validators {
+			validator := &validators[i]
+			if validator.Pubkey == "" {
+				continue
+			}
+
+			info, err := network.validators.Resolve(ctx, validator.Pubkey)
+			if err != nil {
+				restoreValidator(validator, previous, models.SourceValidator)
+				errs = append(errs, fmt.Sprintf("%s: %v", validatorName(validator), err))
+				continue
+			}
+			if info == nil {
+				continue
+			}
+
+			validator.Index = info.Index
+			validator.Status = info.Status
+
+			// Convert balance from string to float64
+			if validatorBalance, err := strconv.ParseFloat(info.Balance, 64); err == nil {
+				validator.StakingBalance = fmt.Sprintf("%.3f", validatorBalance/gwei)
+			}
+		}
+		return joinErrors(errs)
+	})
+	s.resolveSource(balanceObj, previous, models.SourceValidator, now, err)
+
+	// 인덱스로 설정된 검증자가 pubkey로 설정된 검증자와 같으면 한 번만 조회
+	validators = dedupeValidators(validators)
+
+	// Get validator details (인덱스 조회가 실패해도 이전에 알려진 인덱스로 조회)
+	if hasValidatorIndex(validators) {
+		err = s.scrape(addr, network, models.SourceValidatorDetail, func() error {
+			var errs []string
+			for i := range validators {
+				validator := &validators[i]
+				if validator.Index == "" {
+					continue
+				}
+
+				details, err := network.API.GetValidatorDetails(ctx, validator.Index)
+				if err != nil {
+					restoreValidator(validator, previous, models.SourceValidatorDetail)
+					errs = append(errs, fmt.Sprintf("%s: %v", validatorName(validator), err))
+					continue
+				}
+				if details == nil {
+					continue
+				}
+
+				applyValidatorDetails(validator, details, gwei, network.epochsPerDay())
+				validator.LastRewardTime = now.Format(time.RFC3339)
+
+				// 인덱스로만 설정된 검증자는 다음 주기부터 pubkey로 상태를 조회
+				if pubkey := details.Result.Data.JSON.ValidatorPublicKey; pubkey != "" {
+					if validator.Pubkey == "" {
+						validator.Pubkey = pubkey
+					}
+					network.validators.Learn(pubkey, validator.Index)
+				}
+			}
+			return joinErrors(errs)
+		})
+		s.resolveSource(balanceObj, previous, models.SourceValidatorDetail, now, err)
+	}
+
+	aggregateValidators(balanceObj, validators)
+}
+
+// newValidators returns the validators configured for an address, completing
+// pubkeys and indices from the resolver cache
+func (s *BalanceService) newValidators(addr models.Address, network *Network, now time.Time) []models.ValidatorBalance {
+	var validators []models.ValidatorBalance
+	known := make(map[string]bool)
+
+	newValidator := func(pubkey, index string) models.ValidatorBalance {
+		return models.ValidatorBalance{
+			Pubkey:         pubkey,
+			Index:          index,
+			StakingBalance: "0",
+			LastEpoch:      "0",
+			LastRewardTime: now.Format(time.RFC3339),
+			LatestIncome:   "0",
+			DailyReward:    "0",
+		}
+	}
+
+	for _, pubkey := range addr.Pubkeys() {
+		index, _ := network.validators.Index(pubkey)
+		if index != "" {
+			known[index] = true
+		}
+		validators = append(validators, newValidator(pubkey, index))
+	}
+
+	for _, index := range addr.ValidatorIndices {
+		index = strings.TrimSpace(index)
+		if index == "" || known[index] {
+			continue
+		}
+		known[index] = true
+
+		pubkey, _ := network.validators.Pubkey(index)
+		validators = append(validators, newValidator(pubkey, index))
+	}
+
+	return validators
+}
+
+// restoreValidator marks a validator stale and restores the fields provided
+// by a source from its previous state, if any
+func restoreValidator(validator *models.ValidatorBalance, previous *models.Balance, source string) {
+	validator.Stale = true
+	if previous == nil {
+		return
+	}
+
+	for _, prev := range previous.Validators {
+		if sameValidator(validator, &prev) {
+			copyValidatorFields(validator, &prev, source)
+			return
+		}
+	}
+}
+
+// copyValidatorFields copies the fields provided by a source from src to dst
+func copyValidatorFields(dst, src *models.ValidatorBalance, source string) {
+	switch source {
+	case models.SourceValidator:
+		if dst.Index == "" {
+			dst.Index = src.Index
+		}
+		dst.Status = src.Status
+		dst.StakingBalance = src.StakingBalance
+	case models.SourceValidatorDetail:
+		dst.LastEpoch = src.LastEpoch
+		dst.LatestIncome = src.LatestIncome
+		dst.DailyReward = src.DailyReward
+		dst.LastRewardTime = src.LastRewardTime
+	}
+}
+
+// sameValidator reports whether two validator states belong to the same validator
+func sameValidator(a, b *models.ValidatorBalance) bool {
+	if a.Index != "" && a.Index == b.Index {
+		return true
+	}
+	return a.Pubkey != "" && models.NormalizePubkey(a.Pubkey) == models.NormalizePubkey(b.Pubkey)
+}
+
+// dedupeValidators drops validators configured by index whose index turned
+// out to belong to a validator configured by pubkey
+func dedupeValidators(validators []models.ValidatorBalance) []models.ValidatorBalance {
+	byPubkey := make(map[string]bool)
+	for _, validator := range validators {
+		if validator.Pubkey != "" && validator.Index != "" {
+			byPubkey[validator.Index] = true
+		}
+	}
+
+	deduped := validators[:0]
+	for _, validator := range validators {
+		if validator.Pubkey == "" && byPubkey[validator.Index] {
+			continue
+		}
+		deduped = append(deduped, validator)
+	}
+	return deduped
+}
+
+// hasValidatorIndex reports whether the index of any validator is known
+func hasValidatorIndex(validators []models.ValidatorBalance) bool {
+	for _, validator := range validators {
+		if validator.Index != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// aggregateValidators sets the validators of a balance and sums their staking
+// balance and income into the address-level fields
+func aggregateValidators(balanceObj *models.Balance, validators []models.ValidatorBalance) {
+	var stakingBalance, latestIncome, dailyReward float64
+	var lastEpoch int64
+	var lastRewardTime time.Time
+
+	for _, validator := range validators {
+		if val, err := strconv.ParseFloat(validator.StakingBalance, 64); err == nil {
+			stakingBalance += val
+		}
+		if val, err := strconv.ParseFloat(validator.LatestIncome, 64); err == nil {
+			latestIncome += val
+		}
+		if val, err := strconv.ParseFloat(validator.DailyReward, 64); err == nil {
+			dailyReward += val
+		}
+		if epoch, err := strconv.ParseInt(validator.LastEpoch, 10, 64); err == nil && epoch > lastEpoch {
+			lastEpoch = epoch
+		}
+		if rewardTime, err := time.Parse(time.RFC3339, validator.LastRewardTime); err == nil && rewardTime.After(lastRewardTime) {
+			lastRewardTime = rewardTime
+		}
+	}
+
+	balanceObj.Validators = validators
+	balanceObj.StakingBalance = fmt.Sprintf("%.3f", stakingBalance)
+	balanceObj.LatestIncome = fmt.Sprintf("%.4f", latestIncome)
+	balanceObj.DailyReward = fmt.Sprintf("%.4f", dailyReward)
+	balanceObj.LastEpoch = strconv.FormatInt(lastEpoch, 10)
+	if !lastRewardTime.IsZero() {
+		balanceObj.LastRewardTime = lastRewardTime.Format(time.RFC3339)
+	}
+
+	// 검증자가 하나인 경우 기존과 같이 주소 단위 인덱스와 상태를 유지
+	if len(validators) == 1 {
+		balanceObj.ValidatorIndex = validators[0].Index
+		balanceObj.Status = validators[0].Status
+	}
+}
+
+// recordStatusChanges records a lifecycle event for every validator of a
+// balance whose status differs from the status of its last event, or that has
+// no event yet. Stale validators are skipped, since their status is old.
+func (s *BalanceService) recordStatusChanges(ctx context.Context, network *Network, balance *models.Balance, now time.Time) error {
+	// 같은 검증자가 여러 주소에 설정된 경우 이벤트가 중복 기록되지 않도록 직렬화
+	s.eventsMutex.Lock()
+	defer s.eventsMutex.Unlock()
+
+	// 주기마다 저장소를 조회하지 않도록 검증자별 최신 이벤트는 처음 한 번만 읽어 둠
+	if s.latestEvents == nil {
+		events, err := s.repo.LatestValidatorEvents(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load validator events: %v", err)
+		}
+		s.latestEvents = make(map[string]*models.ValidatorEvent, len(events))
+		for _, event := range events {
+			s.latestEvents[eventKey(event.Network, event.ValidatorIdx)] = event
+		}
+	}
+
+	var errs []string
+	for _, validator := range balance.KnownValidators() {
+		if validator.Stale || validator.Status == "" {
+			continue
+		}
+
+		key := eventKey(network.Name, validator.Index)
+		var from string
+		if latest, exists := s.latestEvents[key]; exists {
+			from = latest.To
+		}
+		if from == validator.Status {
+			continue
+		}
+
+		// 체인 시계가 있으면 감지한 시각의 epoch, 없으면 검증자의 마지막 epoch
+		epoch, _ := strconv.ParseUint(validator.LastEpoch, 10, 64)
+		if network.Clock != nil {
+			epoch = network.Clock.EpochAt(now)
+		}
+
+		event := &models.ValidatorEvent{
+			ValidatorIdx: validator.Index,
+			Label:        balance.Label,
+			Network:      network.Name,
+			Kind:         models.EventKind(from, validator.Status),
+			From:         from,
+			To:           validator.Status,
+			Epoch:        epoch,
+			Time:         now,
+		}
+		if err := s.repo.SaveValidatorEvent(ctx, event); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", validatorName(&validator), err))
+			continue
+		}
+		s.latestEvents[key] = event
+		if from != "" {
+			log.Printf("Validator %s on %s changed status from %s to %s in epoch %d", validator.Index, network.Name, from, validator.Status, epoch)
+		}
+	}
+	return joinErrors(errs)
+}
+
+// eventKey identifies a validator across networks, since indices are only
+// unique within one
+func eventKey(network, validatorIdx string) string {
+	return network + "/" + validatorIdx
+}
+
+// validatorName returns the index of a validator, or its pubkey if the index is unknown
+func validatorName(validator *models.ValidatorBalance) string {
+	if validator.Index != "" {
+		return "validator " + validator.Index
+	}
+	return "validator " + validator.Pubkey
+}
+
+// joinErrors combines the errors of several validators into one, or nil
+func joinErrors(errs []string) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s", strings.Join(errs, "; "))
+}
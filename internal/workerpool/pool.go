@@ -0,0 +1,70 @@
+package workerpool
+
+import (
+	"context"
+	"dill-monitor/internal/models"
+	"log"
+	"sync"
+)
+
+// Recorder receives the number of addresses waiting for a worker
+type Recorder interface {
+	RecordQueueDepth(depth int) error
+}
+
+// Pool processes addresses with a fixed number of workers
+type Pool struct {
+	size     int
+	recorder Recorder
+}
+
+// New creates a pool of size workers; recorder may be nil
+func New(size int, recorder Recorder) *Pool {
+	if size <= 0 {
+		size = 1
+	}
+	return &Pool{size: size, recorder: recorder}
+}
+
+// Run calls fn for every address on at most size goroutines and returns once
+// all of them are done. Addresses still queued when ctx is done are dropped.
+func (p *Pool) Run(ctx context.Context, addresses []models.Address, fn func(ctx context.Context, addr models.Address)) {
+	queue := make(chan models.Address, len(addresses))
+	for _, addr := range addresses {
+		queue <- addr
+	}
+	close(queue)
+	p.record(len(queue))
+
+	workers := p.size
+	if workers > len(addresses) {
+		workers = len(addresses)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			for addr := range queue {
+				p.record(len(queue))
+				if ctx.Err() != nil {
+					continue
+				}
+				fn(ctx, addr)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// record reports the queue depth to the recorder, if any
+func (p *Pool) record(depth int) {
+	if p.recorder == nil {
+		return
+	}
+	if err := p.recorder.RecordQueueDepth(depth); err != nil {
+		log.Printf("Error recording queue depth: %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package workerpool
+
+import (
+	"context"
+	"dill-monitor/internal/models"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testRecorder collects the recorded queue depths
+type testRecorder struct {
+	mu     sync.Mutex
+	depths []int
+}
+
+func (r *testRecorder) RecordQueueDepth(depth int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.depths = append(r.depths, depth)
+	return nil
+}
+
+// testAddresses returns n addresses
+func testAddresses(n int) []models.Address {
+	addresses := make([]models.Address, n)
+	for i := range addresses {
+		addresses[i] = models.Address{Address: fmt.Sprintf("0x%d", i)}
+	}
+	return addresses
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name      string
+		size      int
+		addresses int
+		// workers is the expected peak concurrency
+		workers int
+	}{
+		{"bounded", 3, 10, 3},
+		{"fewer addresses than workers", 8, 2, 2},
+		{"non-positive size", 0, 4, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &testRecorder{}
+			pool := New(tt.size, recorder)
+
+			var mu sync.Mutex
+			var running, peak int
+			var processed []string
+			pool.Run(context.Background(), testAddresses(tt.addresses), func(ctx context.Context, addr models.Address) {
+				mu.Lock()
+				running++
+				if running > peak {
+					peak = running
+				}
+				mu.Unlock()
+
+				time.Sleep(10 * time.Millisecond)
+
+				mu.Lock()
+				running--
+				processed = append(processed, addr.Address)
+				mu.Unlock()
+			})
+
+			if len(processed) != tt.addresses {
+				t.Errorf("processed %d addresses, want %d", len(processed), tt.addresses)
+			}
+			if peak != tt.workers {
+				t.Errorf("peak concurrency = %d, want %d", peak, tt.workers)
+			}
+			// 처음에는 모든 주소가 대기하고 마지막에는 대기열이 비어 있음
+			sort.Ints(recorder.depths)
+			if len(recorder.depths) != tt.addresses+1 || recorder.depths[0] != 0 || recorder.depths[tt.addresses] != tt.addresses {
+				t.Errorf("queue depths = %v, want every address recorded from %d to 0", recorder.depths, tt.addresses)
+			}
+		})
+	}
+}
+
+func TestRunCancelled(t *testing.T) {
+	pool := New(1, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// 취소 후 대기 중인 주소는 처리하지 않음
+	var processed int
+	pool.Run(ctx, testAddresses(5), func(ctx context.Context, addr models.Address) {
+		processed++
+		cancel()
+	})
+
+	if processed != 1 {
+		t.Errorf("processed %d addresses, want only the one running when cancelled", processed)
+	}
+}
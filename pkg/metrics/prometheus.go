@@ -1,6 +1,10 @@
 package metrics
 
 import (
+	"strconv"
+	"sync"
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
@@ -33,20 +37,73 @@ type PrometheusClient struct {
 	validatorLastRewardGauge *prometheus.GaugeVec
 	validatorBalanceGauge    *prometheus.GaugeVec
 	validatorStatusInfoGauge *prometheus.GaugeVec
+	validatorStatusChanges   *prometheus.CounterVec
+	validatorStatusSince     *prometheus.GaugeVec
 
 	// Summary metrics
-	totalAddressCountGauge    prometheus.Gauge
-	totalBalanceGauge         prometheus.Gauge
-	totalRewardGauge          prometheus.Gauge
-	totalStakedAmountGauge    prometheus.Gauge
-	totalValidatorCountGauge  prometheus.Gauge
-	activeValidatorCountGauge prometheus.Gauge
+	totalAddressCountGauge    *prometheus.GaugeVec
+	totalBalanceGauge         *prometheus.GaugeVec
+	totalRewardGauge          *prometheus.GaugeVec
+	totalStakedAmountGauge    *prometheus.GaugeVec
+	totalValidatorCountGauge  *prometheus.GaugeVec
+	activeValidatorCountGauge *prometheus.GaugeVec
 	validatorStatusCountGauge *prometheus.GaugeVec
 
 	// API metrics
 	requestCounter  *prometheus.CounterVec
 	requestDuration *prometheus.HistogramVec
 	requestErrors   *prometheus.CounterVec
+
+	// Scrape health metrics
+	scrapeSuccessGauge             *prometheus.GaugeVec
+	scrapeLastSuccessGauge         *prometheus.GaugeVec
+	scrapeDurationGauge            *prometheus.GaugeVec
+	scrapeConsecutiveFailuresGauge *prometheus.GaugeVec
+	scrapeFailuresCounter          *prometheus.CounterVec
+	dataStaleGauge                 *prometheus.GaugeVec
+
+	// Scheduler metrics
+	nextRunGauge  *prometheus.GaugeVec
+	cycleDuration prometheus.Histogram
+	cyclesSkipped prometheus.Counter
+
+	// Upstream retry metrics
+	upstreamRetries  *prometheus.CounterVec
+	upstreamFailures *prometheus.CounterVec
+
+	// Discovery metrics
+	validatorsDiscovered *prometheus.CounterVec
+
+	// Config reload metrics
+	configReloadSuccess   prometheus.Gauge
+	configReloadTimestamp prometheus.Gauge
+	alertThresholds       *prometheus.GaugeVec
+
+	// Worker pool and rate limiter metrics
+	workerQueueDepth prometheus.Gauge
+	rateLimitWait    *prometheus.HistogramVec
+
+	// statusInfo holds the status label of the status series of every
+	// validator, which is needed to delete it on a transition
+	statusInfo map[validatorKey]string
+	// lastStatus holds the last known status of every validator by network
+	// and index, so that transitions are counted once even when the label of
+	// its address changes or several addresses share it
+	lastStatus      map[statusKey]string
+	statusInfoMutex sync.Mutex
+}
+
+// validatorKey identifies the series of one validator
+type validatorKey struct {
+	validatorIdx string
+	label        string
+	network      string
+}
+
+// statusKey identifies a validator independent of the addresses it backs
+type statusKey struct {
+	network      string
+	validatorIdx string
 }
 
 // NewPrometheusClient creates a new Prometheus client with registered metrics
@@ -57,163 +114,183 @@ func NewPrometheusClient() *PrometheusClient {
 				Name: "account_balance",
 				Help: "Current account balance in DILL",
 			},
-			[]string{"address", "label"},
+			[]string{"address", "label", "network"},
 		),
 		stakingBalanceGauge: promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "staking_balance",
 				Help: "Current staking balance in DILL",
 			},
-			[]string{"address", "label"},
+			[]string{"address", "label", "network"},
 		),
 		stakedAmountGauge: promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "staked_amount",
 				Help: "Total staked amount in DILL",
 			},
-			[]string{"address", "label"},
+			[]string{"address", "label", "network"},
 		),
 		rewardGauge: promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "reward_amount",
 				Help: "Current reward amount in DILL",
 			},
-			[]string{"address", "label"},
+			[]string{"address", "label", "network"},
 		),
 		dailyRewardGauge: promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "daily_reward_amount",
 				Help: "Daily reward amount in DILL",
 			},
-			[]string{"address", "label"},
+			[]string{"address", "label", "network"},
 		),
 		latestIncomeGauge: promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "latest_income_amount",
 				Help: "Latest income amount in DILL",
 			},
-			[]string{"address", "label"},
+			[]string{"address", "label", "network"},
 		),
 		lastEpochGauge: promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "last_epoch",
 				Help: "Last epoch number",
 			},
-			[]string{"address", "label"},
+			[]string{"address", "label", "network"},
 		),
 		lastRewardTimeGauge: promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "last_reward_time",
 				Help: "Last reward time as unix timestamp",
 			},
-			[]string{"address", "label"},
+			[]string{"address", "label", "network"},
 		),
 		poolCreatedCountGauge: promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "pool_created_count",
 				Help: "Number of pools created",
 			},
-			[]string{"address", "label"},
+			[]string{"address", "label", "network"},
 		),
 		poolParticipatedCountGauge: promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "pool_participated_count",
 				Help: "Number of pools participated in",
 			},
-			[]string{"address", "label"},
+			[]string{"address", "label", "network"},
 		),
 		validatorRewardGauge: promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "validator_reward",
 				Help: "Validator reward amount in DILL",
 			},
-			[]string{"validator_idx", "label"},
+			[]string{"validator_idx", "label", "network"},
 		),
 		validatorStatusGauge: promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "validator_status",
 				Help: "Validator status (1 for active, 0 for inactive)",
 			},
-			[]string{"validator_idx", "label"},
+			[]string{"validator_idx", "label", "network"},
 		),
 		validatorLastEpochGauge: promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "validator_last_epoch",
 				Help: "Validator's last processed epoch",
 			},
-			[]string{"validator_idx", "label"},
+			[]string{"validator_idx", "label", "network"},
 		),
 		validatorLastRewardGauge: promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "validator_last_reward_time",
 				Help: "Validator's last reward time as unix timestamp",
 			},
-			[]string{"validator_idx", "label"},
+			[]string{"validator_idx", "label", "network"},
 		),
 		validatorBalanceGauge: promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "validator_balance",
 				Help: "Validator's balance in DILL",
 			},
-			[]string{"validator_idx", "label"},
+			[]string{"validator_idx", "label", "network"},
 		),
 		validatorStatusInfoGauge: promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "validator_status_info",
 				Help: "Validator status information",
 			},
-			[]string{"validator_idx", "label", "status"},
+			[]string{"validator_idx", "label", "status", "network"},
+		),
+		validatorStatusChanges: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "validator_status_changes_total",
+				Help: "Total number of validator status transitions",
+			},
+			[]string{"from", "to", "network"},
+		),
+		validatorStatusSince: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "validator_status_since_timestamp_seconds",
+				Help: "Unix timestamp since which the validator has its current status",
+			},
+			[]string{"validator_idx", "label", "network"},
 		),
 		// Summary metrics
-		totalAddressCountGauge: promauto.NewGauge(
+		totalAddressCountGauge: promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "total_address_count",
 				Help: "Total number of addresses being monitored",
 			},
+			[]string{"network"},
 		),
-		totalBalanceGauge: promauto.NewGauge(
+		totalBalanceGauge: promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "total_balance",
 				Help: "Total balance across all addresses in DILL",
 			},
+			[]string{"network"},
 		),
-		totalRewardGauge: promauto.NewGauge(
+		totalRewardGauge: promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "total_reward",
 				Help: "Total rewards across all addresses in DILL",
 			},
+			[]string{"network"},
 		),
-		totalStakedAmountGauge: promauto.NewGauge(
+		totalStakedAmountGauge: promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "total_staked_amount",
 				Help: "Total staked amount across all addresses in DILL",
 			},
+			[]string{"network"},
 		),
-		totalValidatorCountGauge: promauto.NewGauge(
+		totalValidatorCountGauge: promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "total_validator_count",
 				Help: "Total number of validators",
 			},
+			[]string{"network"},
 		),
-		activeValidatorCountGauge: promauto.NewGauge(
+		activeValidatorCountGauge: promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "active_validator_count",
 				Help: "Total number of active validators",
 			},
+			[]string{"network"},
 		),
 		validatorStatusCountGauge: promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "validator_status_count",
 				Help: "Number of validators in each status",
 			},
-			[]string{"status"},
+			[]string{"status", "network"},
 		),
 		requestCounter: promauto.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "http_requests_total",
 				Help: "Total number of HTTP requests",
 			},
-			[]string{"endpoint", "method", "status"},
+			[]string{"endpoint", "method", "status", "network"},
 		),
 		requestDuration: promauto.NewHistogramVec(
 			prometheus.HistogramOpts{
@@ -221,15 +298,133 @@ func NewPrometheusClient() *PrometheusClient {
 				Help:    "HTTP request duration in seconds",
 				Buckets: prometheus.DefBuckets,
 			},
-			[]string{"endpoint", "method"},
+			[]string{"endpoint", "method", "network"},
 		),
 		requestErrors: promauto.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "http_request_errors_total",
 				Help: "Total number of HTTP request errors",
 			},
-			[]string{"endpoint", "method", "error_type"},
+			[]string{"endpoint", "method", "error_type", "network"},
+		),
+		scrapeSuccessGauge: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "dill_scrape_success",
+				Help: "Whether the last scrape of a source succeeded (1) or failed (0)",
+			},
+			[]string{"address", "label", "source", "network"},
+		),
+		scrapeLastSuccessGauge: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "dill_scrape_last_success_timestamp_seconds",
+				Help: "Unix timestamp of the last successful scrape of a source",
+			},
+			[]string{"address", "label", "source", "network"},
 		),
+		scrapeDurationGauge: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "dill_scrape_duration_seconds",
+				Help: "Duration of the last scrape of a source in seconds",
+			},
+			[]string{"address", "label", "source", "network"},
+		),
+		scrapeConsecutiveFailuresGauge: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "dill_scrape_consecutive_failures",
+				Help: "Number of consecutive failed scrapes of a source",
+			},
+			[]string{"address", "label", "source", "network"},
+		),
+		scrapeFailuresCounter: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "dill_scrape_failures_total",
+				Help: "Total number of failed scrapes of a source",
+			},
+			[]string{"address", "label", "source", "network"},
+		),
+		dataStaleGauge: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "dill_data_stale",
+				Help: "Whether the exported values of a source are stale (1) because its last fetch failed",
+			},
+			[]string{"address", "label", "source", "network"},
+		),
+		nextRunGauge: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "dill_next_run_timestamp_seconds",
+				Help: "Unix timestamp of the next scheduled processing of an address",
+			},
+			[]string{"address", "label", "network"},
+		),
+		cycleDuration: promauto.NewHistogram(
+			prometheus.HistogramOpts{
+				Name:    "dill_cycle_duration_seconds",
+				Help:    "Duration of processing cycles",
+				Buckets: []float64{0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600},
+			},
+		),
+		cyclesSkipped: promauto.NewCounter(
+			prometheus.CounterOpts{
+				Name: "dill_cycle_skipped_total",
+				Help: "Total number of cycles skipped because the previous cycle was still running",
+			},
+		),
+		upstreamRetries: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "dill_upstream_retries_total",
+				Help: "Total number of retried upstream API requests",
+			},
+			[]string{"endpoint", "network"},
+		),
+		upstreamFailures: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "dill_upstream_failures_total",
+				Help: "Total number of upstream API requests that failed after all retries",
+			},
+			[]string{"endpoint", "network"},
+		),
+		validatorsDiscovered: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "validator_discovered_total",
+				Help: "Total number of validators discovered from deposits to a withdrawal address",
+			},
+			[]string{"address", "label", "network"},
+		),
+		configReloadSuccess: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "dill_config_last_reload_success",
+				Help: "Whether the last config reload attempt succeeded",
+			},
+		),
+		configReloadTimestamp: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "dill_config_last_reload_timestamp_seconds",
+				Help: "Unix timestamp of the last successful config reload",
+			},
+		),
+		alertThresholds: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "dill_alert_threshold",
+				Help: "Alert thresholds from the alerts section of the config, for use in alerting rules",
+			},
+			[]string{"alert"},
+		),
+		workerQueueDepth: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "dill_worker_queue_depth",
+				Help: "Number of due addresses waiting for a worker",
+			},
+		),
+		rateLimitWait: promauto.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "dill_upstream_rate_limit_wait_seconds",
+				Help:    "Time upstream requests waited for the rate limiter of their host",
+				Buckets: []float64{0, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+			},
+			[]string{"host"},
+		),
+		statusInfo: make(map[validatorKey]string),
+		lastStatus: make(map[statusKey]string),
 	}
 }
 
@@ -237,6 +432,7 @@ func NewPrometheusClient() *PrometheusClient {
 func (c *PrometheusClient) UpdateBalanceMetrics(
 	address string,
 	label string,
+	network string,
 	balance,
 	stakingBalance,
 	stakedAmount,
@@ -248,22 +444,23 @@ func (c *PrometheusClient) UpdateBalanceMetrics(
 	poolCreatedCount float64,
 	poolParticipatedCount float64,
 ) {
-	c.balanceGauge.WithLabelValues(address, label).Set(balance)
-	c.stakingBalanceGauge.WithLabelValues(address, label).Set(stakingBalance)
-	c.stakedAmountGauge.WithLabelValues(address, label).Set(stakedAmount)
-	c.rewardGauge.WithLabelValues(address, label).Set(reward)
-	c.dailyRewardGauge.WithLabelValues(address, label).Set(dailyReward)
-	c.latestIncomeGauge.WithLabelValues(address, label).Set(latestIncome)
-	c.lastEpochGauge.WithLabelValues(address, label).Set(lastEpoch)
-	c.lastRewardTimeGauge.WithLabelValues(address, label).Set(lastRewardTime)
-	c.poolCreatedCountGauge.WithLabelValues(address, label).Set(poolCreatedCount)
-	c.poolParticipatedCountGauge.WithLabelValues(address, label).Set(poolParticipatedCount)
+	c.balanceGauge.WithLabelValues(address, label, network).Set(balance)
+	c.stakingBalanceGauge.WithLabelValues(address, label, network).Set(stakingBalance)
+	c.stakedAmountGauge.WithLabelValues(address, label, network).Set(stakedAmount)
+	c.rewardGauge.WithLabelValues(address, label, network).Set(reward)
+	c.dailyRewardGauge.WithLabelValues(address, label, network).Set(dailyReward)
+	c.latestIncomeGauge.WithLabelValues(address, label, network).Set(latestIncome)
+	c.lastEpochGauge.WithLabelValues(address, label, network).Set(lastEpoch)
+	c.lastRewardTimeGauge.WithLabelValues(address, label, network).Set(lastRewardTime)
+	c.poolCreatedCountGauge.WithLabelValues(address, label, network).Set(poolCreatedCount)
+	c.poolParticipatedCountGauge.WithLabelValues(address, label, network).Set(poolParticipatedCount)
 }
 
 // UpdateBasicMetrics updates only basic metrics that should always be shown
 func (c *PrometheusClient) UpdateBasicMetrics(
 	address string,
 	label string,
+	network string,
 	balance float64,
 	stakedAmount float64,
 	reward float64,
@@ -271,33 +468,35 @@ func (c *PrometheusClient) UpdateBasicMetrics(
 	poolParticipatedCount float64,
 	lastRewardTime float64,
 ) {
-	c.balanceGauge.WithLabelValues(address, label).Set(balance)
-	c.stakedAmountGauge.WithLabelValues(address, label).Set(stakedAmount)
-	c.rewardGauge.WithLabelValues(address, label).Set(reward)
-	c.lastRewardTimeGauge.WithLabelValues(address, label).Set(lastRewardTime)
-	c.poolCreatedCountGauge.WithLabelValues(address, label).Set(poolCreatedCount)
-	c.poolParticipatedCountGauge.WithLabelValues(address, label).Set(poolParticipatedCount)
+	c.balanceGauge.WithLabelValues(address, label, network).Set(balance)
+	c.stakedAmountGauge.WithLabelValues(address, label, network).Set(stakedAmount)
+	c.rewardGauge.WithLabelValues(address, label, network).Set(reward)
+	c.lastRewardTimeGauge.WithLabelValues(address, label, network).Set(lastRewardTime)
+	c.poolCreatedCountGauge.WithLabelValues(address, label, network).Set(poolCreatedCount)
+	c.poolParticipatedCountGauge.WithLabelValues(address, label, network).Set(poolParticipatedCount)
 }
 
 // UpdateValidatorRelatedMetrics updates metrics that should only be shown for accounts with validators
 func (c *PrometheusClient) UpdateValidatorRelatedMetrics(
 	address string,
 	label string,
+	network string,
 	stakingBalance float64,
 	dailyReward float64,
 	latestIncome float64,
 	lastEpoch float64,
 ) {
-	c.stakingBalanceGauge.WithLabelValues(address, label).Set(stakingBalance)
-	c.dailyRewardGauge.WithLabelValues(address, label).Set(dailyReward)
-	c.latestIncomeGauge.WithLabelValues(address, label).Set(latestIncome)
-	c.lastEpochGauge.WithLabelValues(address, label).Set(lastEpoch)
+	c.stakingBalanceGauge.WithLabelValues(address, label, network).Set(stakingBalance)
+	c.dailyRewardGauge.WithLabelValues(address, label, network).Set(dailyReward)
+	c.latestIncomeGauge.WithLabelValues(address, label, network).Set(latestIncome)
+	c.lastEpochGauge.WithLabelValues(address, label, network).Set(lastEpoch)
 }
 
 // UpdateValidatorMetrics updates validator-related metrics
 func (c *PrometheusClient) UpdateValidatorMetrics(
 	validatorIdx string,
 	label string,
+	network string,
 	reward float64,
 	balance float64,
 	isActive bool,
@@ -305,42 +504,226 @@ func (c *PrometheusClient) UpdateValidatorMetrics(
 	lastRewardTime float64,
 	statusString string,
 ) {
-	c.validatorRewardGauge.WithLabelValues(validatorIdx, label).Set(reward)
-	c.validatorBalanceGauge.WithLabelValues(validatorIdx, label).Set(balance)
+	c.validatorRewardGauge.WithLabelValues(validatorIdx, label, network).Set(reward)
+	c.validatorBalanceGauge.WithLabelValues(validatorIdx, label, network).Set(balance)
 	status := ValidatorStatusInactive
 	if isActive {
 		status = ValidatorStatusActive
 	}
-	c.validatorStatusGauge.WithLabelValues(validatorIdx, label).Set(status)
-	c.validatorLastEpochGauge.WithLabelValues(validatorIdx, label).Set(lastEpoch)
-	c.validatorLastRewardGauge.WithLabelValues(validatorIdx, label).Set(lastRewardTime)
+	c.validatorStatusGauge.WithLabelValues(validatorIdx, label, network).Set(status)
+	c.validatorLastEpochGauge.WithLabelValues(validatorIdx, label, network).Set(lastEpoch)
+	c.validatorLastRewardGauge.WithLabelValues(validatorIdx, label, network).Set(lastRewardTime)
 
 	// 상태 정보 업데이트
-	c.UpdateValidatorStatusInfo(validatorIdx, label, statusString)
+	c.UpdateValidatorStatusInfo(validatorIdx, label, network, statusString)
 }
 
-// UpdateValidatorStatusInfo updates the validator status information
-func (c *PrometheusClient) UpdateValidatorStatusInfo(validatorIdx string, label string, statusString string) {
+// UpdateValidatorStatusInfo updates the validator status information. The
+// series of the previous status is deleted and the transition counted.
+func (c *PrometheusClient) UpdateValidatorStatusInfo(validatorIdx string, label string, network string, statusString string) {
 	// status가 비어있는 경우 unknown으로 처리
 	if statusString == "" {
 		statusString = "unknown"
 	}
 
+	key := validatorKey{validatorIdx, label, network}
+	c.statusInfoMutex.Lock()
+	defer c.statusInfoMutex.Unlock()
+
+	// 상태가 바뀌면 이전 상태의 시리즈를 삭제해 한 검증자당 하나의 시리즈만 유지
+	if previous, exists := c.statusInfo[key]; exists && previous != statusString {
+		c.validatorStatusInfoGauge.DeleteLabelValues(validatorIdx, label, previous, network)
+	}
+
+	// 상태 전환은 라벨과 무관하게 네트워크와 인덱스 기준으로 한 번만 집계
+	last := statusKey{network, validatorIdx}
+	if previous, exists := c.lastStatus[last]; exists && previous != statusString {
+		c.validatorStatusChanges.WithLabelValues(previous, statusString, network).Inc()
+	}
+
 	// 새 상태 정보 설정 (값은 1로 고정, 라벨에 상태 정보 포함)
-	c.validatorStatusInfoGauge.WithLabelValues(validatorIdx, label, statusString).Set(1)
+	c.validatorStatusInfoGauge.WithLabelValues(validatorIdx, label, statusString, network).Set(1)
+	c.statusInfo[key] = statusString
+	c.lastStatus[last] = statusString
+}
+
+// UpdateValidatorStatusSince sets the time since which a validator has its current status
+func (c *PrometheusClient) UpdateValidatorStatusSince(validatorIdx, label, network string, since time.Time) {
+	c.validatorStatusSince.WithLabelValues(validatorIdx, label, network).Set(float64(since.Unix()))
+}
+
+// RecordAPIMetrics records the status and latency of an upstream API request.
+// A status of 0 means no response was received.
+func (c *PrometheusClient) RecordAPIMetrics(network, endpoint, method string, status int, duration float64) {
+	statusLabel := "none"
+	if status > 0 {
+		statusLabel = strconv.Itoa(status)
+	}
+	c.requestCounter.WithLabelValues(endpoint, method, statusLabel, network).Inc()
+	c.requestDuration.WithLabelValues(endpoint, method, network).Observe(duration)
+}
+
+// RecordAPIError records a classified upstream API error
+func (c *PrometheusClient) RecordAPIError(network, endpoint, method, errorType string) {
+	c.requestErrors.WithLabelValues(endpoint, method, errorType, network).Inc()
+}
+
+// RecordScrape records the outcome and duration of scraping one source of an address
+func (c *PrometheusClient) RecordScrape(address, label, network, source string, success bool, duration float64, at time.Time) {
+	c.scrapeDurationGauge.WithLabelValues(address, label, source, network).Set(duration)
+
+	if success {
+		c.scrapeSuccessGauge.WithLabelValues(address, label, source, network).Set(1)
+		c.scrapeLastSuccessGauge.WithLabelValues(address, label, source, network).Set(float64(at.Unix()))
+		c.scrapeConsecutiveFailuresGauge.WithLabelValues(address, label, source, network).Set(0)
+		return
+	}
+
+	c.scrapeSuccessGauge.WithLabelValues(address, label, source, network).Set(0)
+	c.scrapeConsecutiveFailuresGauge.WithLabelValues(address, label, source, network).Inc()
+	c.scrapeFailuresCounter.WithLabelValues(address, label, source, network).Inc()
+}
+
+// UpdateDataStaleness marks the exported values of a source as stale or fresh
+func (c *PrometheusClient) UpdateDataStaleness(address, label, network, source string, stale bool) {
+	value := 0.0
+	if stale {
+		value = 1.0
+	}
+	c.dataStaleGauge.WithLabelValues(address, label, source, network).Set(value)
+}
+
+// UpdateNextRun sets the next scheduled processing time of an address
+func (c *PrometheusClient) UpdateNextRun(address, label, network string, next time.Time) {
+	c.nextRunGauge.WithLabelValues(address, label, network).Set(float64(next.Unix()))
 }
 
-// RecordAPIMetrics records API-related metrics
-func (c *PrometheusClient) RecordAPIMetrics(endpoint, method string, status int, duration float64) {
-	c.requestCounter.WithLabelValues(endpoint, method, string(status)).Inc()
-	c.requestDuration.WithLabelValues(endpoint, method).Observe(duration)
-	if status >= 400 {
-		c.requestErrors.WithLabelValues(endpoint, method, "http_error").Inc()
+// RecordCycleDuration records the duration of a processing cycle
+func (c *PrometheusClient) RecordCycleDuration(duration float64) {
+	c.cycleDuration.Observe(duration)
+}
+
+// RecordCycleSkipped counts a cycle skipped because the previous one was still running
+func (c *PrometheusClient) RecordCycleSkipped() {
+	c.cyclesSkipped.Inc()
+}
+
+// RecordUpstreamRetry counts a retried upstream request
+func (c *PrometheusClient) RecordUpstreamRetry(network, endpoint string) {
+	c.upstreamRetries.WithLabelValues(endpoint, network).Inc()
+}
+
+// RecordUpstreamFailure counts an upstream request that failed after all retries
+func (c *PrometheusClient) RecordUpstreamFailure(network, endpoint string) {
+	c.upstreamFailures.WithLabelValues(endpoint, network).Inc()
+}
+
+// RecordValidatorDiscovered counts a validator discovered for an address
+func (c *PrometheusClient) RecordValidatorDiscovered(address, label, network string) {
+	c.validatorsDiscovered.WithLabelValues(address, label, network).Inc()
+}
+
+// RecordConfigReload records the outcome of a config reload attempt
+func (c *PrometheusClient) RecordConfigReload(success bool, at time.Time) {
+	if !success {
+		c.configReloadSuccess.Set(0)
+		return
+	}
+	c.configReloadSuccess.Set(1)
+	c.configReloadTimestamp.Set(float64(at.Unix()))
+}
+
+// SetAlertThresholds replaces the exported alert thresholds
+func (c *PrometheusClient) SetAlertThresholds(thresholds map[string]float64) {
+	c.alertThresholds.Reset()
+	for alert, value := range thresholds {
+		c.alertThresholds.WithLabelValues(alert).Set(value)
+	}
+}
+
+// DeleteAddressMetrics deletes every series of an address exported with the
+// given label and network, including the scrape health series of the sources
+func (c *PrometheusClient) DeleteAddressMetrics(address, label, network string, sources []string) {
+	for _, vec := range []*prometheus.GaugeVec{
+		c.balanceGauge,
+		c.stakingBalanceGauge,
+		c.stakedAmountGauge,
+		c.rewardGauge,
+		c.dailyRewardGauge,
+		c.latestIncomeGauge,
+		c.lastEpochGauge,
+		c.lastRewardTimeGauge,
+		c.poolCreatedCountGauge,
+		c.poolParticipatedCountGauge,
+		c.nextRunGauge,
+	} {
+		vec.DeleteLabelValues(address, label, network)
+	}
+	c.validatorsDiscovered.DeleteLabelValues(address, label, network)
+
+	for _, source := range sources {
+		for _, vec := range []*prometheus.GaugeVec{
+			c.scrapeSuccessGauge,
+			c.scrapeLastSuccessGauge,
+			c.scrapeDurationGauge,
+			c.scrapeConsecutiveFailuresGauge,
+			c.dataStaleGauge,
+		} {
+			vec.DeleteLabelValues(address, label, source, network)
+		}
+		c.scrapeFailuresCounter.DeleteLabelValues(address, label, source, network)
+	}
+}
+
+// DeleteValidatorMetrics deletes every series of a validator exported with the given label and network
+func (c *PrometheusClient) DeleteValidatorMetrics(validatorIdx, label, network string) {
+	for _, vec := range []*prometheus.GaugeVec{
+		c.validatorRewardGauge,
+		c.validatorBalanceGauge,
+		c.validatorStatusGauge,
+		c.validatorLastEpochGauge,
+		c.validatorLastRewardGauge,
+		c.validatorStatusSince,
+	} {
+		vec.DeleteLabelValues(validatorIdx, label, network)
+	}
+
+	key := validatorKey{validatorIdx, label, network}
+	c.statusInfoMutex.Lock()
+	if status, exists := c.statusInfo[key]; exists {
+		c.validatorStatusInfoGauge.DeleteLabelValues(validatorIdx, label, status, network)
+		delete(c.statusInfo, key)
 	}
+	c.statusInfoMutex.Unlock()
+}
+
+// DeleteSummaryMetrics deletes the summary series of a network
+func (c *PrometheusClient) DeleteSummaryMetrics(network string) {
+	for _, vec := range []*prometheus.GaugeVec{
+		c.totalAddressCountGauge,
+		c.totalValidatorCountGauge,
+		c.activeValidatorCountGauge,
+		c.totalBalanceGauge,
+		c.totalRewardGauge,
+		c.totalStakedAmountGauge,
+	} {
+		vec.DeleteLabelValues(network)
+	}
+}
+
+// UpdateWorkerQueueDepth sets the number of addresses waiting for a worker
+func (c *PrometheusClient) UpdateWorkerQueueDepth(depth int) {
+	c.workerQueueDepth.Set(float64(depth))
+}
+
+// RecordRateLimitWait records the time a request waited for the rate limiter of its host
+func (c *PrometheusClient) RecordRateLimitWait(host string, wait float64) {
+	c.rateLimitWait.WithLabelValues(host).Observe(wait)
 }
 
-// UpdateSummaryMetrics updates summary metrics with aggregated data
+// UpdateSummaryMetrics updates summary metrics of a network with aggregated data
 func (c *PrometheusClient) UpdateSummaryMetrics(
+	network string,
 	addressCount int,
 	validatorCount int,
 	activeValidatorCount int,
@@ -348,21 +731,23 @@ func (c *PrometheusClient) UpdateSummaryMetrics(
 	totalReward float64,
 	totalStakedAmount float64,
 ) {
-	c.totalAddressCountGauge.Set(float64(addressCount))
-	c.totalValidatorCountGauge.Set(float64(validatorCount))
-	c.activeValidatorCountGauge.Set(float64(activeValidatorCount))
-	c.totalBalanceGauge.Set(totalBalance)
-	c.totalRewardGauge.Set(totalReward)
-	c.totalStakedAmountGauge.Set(totalStakedAmount)
+	c.totalAddressCountGauge.WithLabelValues(network).Set(float64(addressCount))
+	c.totalValidatorCountGauge.WithLabelValues(network).Set(float64(validatorCount))
+	c.activeValidatorCountGauge.WithLabelValues(network).Set(float64(activeValidatorCount))
+	c.totalBalanceGauge.WithLabelValues(network).Set(totalBalance)
+	c.totalRewardGauge.WithLabelValues(network).Set(totalReward)
+	c.totalStakedAmountGauge.WithLabelValues(network).Set(totalStakedAmount)
 }
 
-// UpdateValidatorStatusMetrics updates the count of validators by status
-func (c *PrometheusClient) UpdateValidatorStatusMetrics(statusCounts map[string]int) {
+// UpdateValidatorStatusMetrics updates the count of validators by network and status
+func (c *PrometheusClient) UpdateValidatorStatusMetrics(statusCounts map[string]map[string]int) {
 	// 모든 상태 카운터를 0으로 초기화 (기존 값 제거)
 	c.validatorStatusCountGauge.Reset()
 
-	// 각 상태별 카운트 설정
-	for status, count := range statusCounts {
-		c.validatorStatusCountGauge.WithLabelValues(status).Set(float64(count))
+	// 각 네트워크, 상태별 카운트 설정
+	for network, counts := range statusCounts {
+		for status, count := range counts {
+			c.validatorStatusCountGauge.WithLabelValues(status, network).Set(float64(count))
+		}
 	}
 }
@@ -0,0 +1,128 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// metrics are registered globally, so every test shares one client
+var testClient = NewPrometheusClient()
+
+func TestRecordScrape(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name     string
+		outcomes []bool
+		// success, consecutiveFailures and failures are the expected metric values
+		success             float64
+		consecutiveFailures float64
+		failures            float64
+		// lastSuccess is the index of the last successful outcome, or -1
+		lastSuccess int
+	}{
+		{"success", []bool{true}, 1, 0, 0, 0},
+		{"failure", []bool{false}, 0, 1, 1, -1},
+		{"consecutive failures", []bool{true, false, false, false}, 0, 3, 3, 0},
+		{"recovered", []bool{false, false, true}, 1, 0, 2, 2},
+		{"failed again", []bool{false, true, false}, 0, 1, 2, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 하위 테스트마다 다른 주소로 시리즈를 분리
+			address, label, network, source := tt.name, "label", "alps", "balance"
+			for i, success := range tt.outcomes {
+				testClient.RecordScrape(address, label, network, source, success, 0.5, start.Add(time.Duration(i)*time.Minute))
+			}
+
+			check := func(metric string, got, want float64) {
+				t.Helper()
+				if got != want {
+					t.Errorf("%s = %v, want %v", metric, got, want)
+				}
+			}
+			check("dill_scrape_success", testutil.ToFloat64(testClient.scrapeSuccessGauge.WithLabelValues(address, label, source, network)), tt.success)
+			check("dill_scrape_consecutive_failures", testutil.ToFloat64(testClient.scrapeConsecutiveFailuresGauge.WithLabelValues(address, label, source, network)), tt.consecutiveFailures)
+			check("dill_scrape_failures_total", testutil.ToFloat64(testClient.scrapeFailuresCounter.WithLabelValues(address, label, source, network)), tt.failures)
+			check("dill_scrape_duration_seconds", testutil.ToFloat64(testClient.scrapeDurationGauge.WithLabelValues(address, label, source, network)), 0.5)
+
+			var lastSuccess float64
+			if tt.lastSuccess >= 0 {
+				lastSuccess = float64(start.Add(time.Duration(tt.lastSuccess) * time.Minute).Unix())
+			}
+			check("dill_scrape_last_success_timestamp_seconds", testutil.ToFloat64(testClient.scrapeLastSuccessGauge.WithLabelValues(address, label, source, network)), lastSuccess)
+		})
+	}
+}
+
+func TestUpdateValidatorStatusInfo(t *testing.T) {
+	type update struct{ label, status string }
+
+	tests := []struct {
+		name    string
+		updates []update
+		// changes maps "from->to" to the expected number of transitions
+		changes map[string]float64
+	}{
+		{"first status", []update{{"a", "active_ongoing"}}, map[string]float64{}},
+		{"unchanged", []update{{"a", "active_ongoing"}, {"a", "active_ongoing"}}, map[string]float64{}},
+		{
+			name:    "transitions",
+			updates: []update{{"a", "pending_queued"}, {"a", "active_ongoing"}, {"a", "active_exiting"}},
+			changes: map[string]float64{"pending_queued->active_ongoing": 1, "active_ongoing->active_exiting": 1},
+		},
+		{
+			name:    "label renamed",
+			updates: []update{{"a", "active_ongoing"}, {"b", "active_exiting"}},
+			changes: map[string]float64{"active_ongoing->active_exiting": 1},
+		},
+		{
+			// 여러 주소가 공유하는 검증자의 전환은 한 번만 집계
+			name:    "shared by two addresses",
+			updates: []update{{"a", "active_ongoing"}, {"b", "active_ongoing"}, {"a", "active_exiting"}, {"b", "active_exiting"}},
+			changes: map[string]float64{"active_ongoing->active_exiting": 1},
+		},
+		{"empty status", []update{{"a", "active_ongoing"}, {"a", ""}}, map[string]float64{"active_ongoing->unknown": 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 하위 테스트마다 다른 네트워크로 카운터를 분리
+			network := tt.name
+			for _, u := range tt.updates {
+				testClient.UpdateValidatorStatusInfo("17021", u.label, network, u.status)
+			}
+
+			statuses := []string{"pending_queued", "active_ongoing", "active_exiting", "unknown"}
+			for _, from := range statuses {
+				for _, to := range statuses {
+					if from == to {
+						continue
+					}
+					got := testutil.ToFloat64(testClient.validatorStatusChanges.WithLabelValues(from, to, network))
+					if want := tt.changes[from+"->"+to]; got != want {
+						t.Errorf("%s->%s transitions = %v, want %v", from, to, got, want)
+					}
+				}
+			}
+
+			// 라벨마다 현재 상태의 시리즈 하나만 남음
+			last := tt.updates[len(tt.updates)-1]
+			status := last.status
+			if status == "" {
+				status = "unknown"
+			}
+			if got := testutil.ToFloat64(testClient.validatorStatusInfoGauge.WithLabelValues("17021", last.label, status, network)); got != 1 {
+				t.Errorf("status series of %s = %v, want 1", status, got)
+			}
+			for _, other := range statuses {
+				if other != status && testClient.validatorStatusInfoGauge.DeleteLabelValues("17021", last.label, other, network) {
+					t.Errorf("status series of %s left behind", other)
+				}
+			}
+		})
+	}
+}